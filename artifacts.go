@@ -0,0 +1,42 @@
+package clustertest
+
+import (
+	"context"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/artifacts"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// CollectArtifacts collects debugging artifacts for the given cluster from both the MC and the WC into a new directory
+// within `dir` and returns the path to it (or to the tarball if `artifacts.WithArchive` is used).
+// See the `artifacts` package for details on what is collected.
+//
+// If no client is available for the WC only artifacts from the MC are collected.
+//
+// Example:
+//
+//	path, err := framework.CollectArtifacts(ctx, cluster, "/tmp/artifacts", artifacts.WithArchive())
+func (f *Framework) CollectArtifacts(ctx context.Context, cluster *application.Cluster, dir string, opts ...artifacts.Option) (string, error) {
+	target := artifacts.Target{
+		Cluster: cluster,
+		MC:      f.MC(),
+	}
+
+	wcClient, err := f.WC(cluster.Name)
+	if err != nil {
+		logger.Log("Unable to collect artifacts from workload cluster '%s' - %v", cluster.Name, err)
+	} else {
+		target.WC = wcClient
+	}
+
+	f.registry.mu.RLock()
+	for _, clusterState := range f.registry.clusters {
+		if clusterState.Cluster.Name == cluster.Name {
+			target.ReleaseName = clusterState.ReleaseName
+		}
+	}
+	f.registry.mu.RUnlock()
+
+	return artifacts.NewCollector(dir, opts...).Collect(ctx, target)
+}
@@ -0,0 +1,64 @@
+// e2e-janitor finds and removes E2E test resources left behind on a Management Cluster.
+//
+// The kubeconfig for the Management Cluster is taken from the `E2E_KUBECONFIG` env var.
+//
+// Usage:
+//
+//	E2E_KUBECONFIG=/path/to/kubeconfig e2e-janitor -context my-mc -max-age 12h -pr 123 -dry-run=false
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5"
+	"github.com/giantswarm/clustertest/v5/pkg/janitor"
+)
+
+func main() {
+	contextName := flag.String("context", "", "The kubeconfig context of the Management Cluster to clean up")
+	maxAge := flag.Duration("max-age", janitor.DefaultMaxAge, "Only remove resources older than this")
+	pipelineRun := flag.String("pipeline-run", "", "Only remove resources created by this Tekton PipelineRun")
+	pr := flag.String("pr", "", "Only remove resources created for this pull request number")
+	dryRun := flag.Bool("dry-run", true, "Only report what would be deleted")
+	timeout := flag.Duration("timeout", 1*time.Hour, "The maximum time to spend cleaning up")
+	flag.Parse()
+
+	framework, err := clustertest.New(*contextName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize framework: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report, err := janitor.New(framework,
+		janitor.WithMaxAge(*maxAge),
+		janitor.WithPipelineRun(*pipelineRun),
+		janitor.WithPR(*pr),
+		janitor.WithDryRun(*dryRun),
+	).Run(ctx)
+
+	action := "Deleted"
+	if *dryRun {
+		action = "Would delete"
+	}
+	for _, org := range report.Organizations {
+		fmt.Printf("%s Organization: %s\n", action, org)
+	}
+	for _, cluster := range report.Clusters {
+		fmt.Printf("%s Cluster: %s\n", action, cluster)
+	}
+	for _, release := range report.Releases {
+		fmt.Printf("%s Release: %s\n", action, release)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to clean up all resources: %v\n", err)
+		os.Exit(1)
+	}
+}
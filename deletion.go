@@ -0,0 +1,354 @@
+package clustertest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/wait"
+)
+
+// diagnosticsTimeout is the max time spent gathering diagnostics once a deletion has already timed out
+const diagnosticsTimeout = 2 * time.Minute
+
+// KnownSafeFinalizers are finalizers that are safe to remove from leftover resources once a test cluster is being torn
+// down, as they don't guard any external infrastructure. They are only removed when force deletion is enabled.
+var KnownSafeFinalizers = []string{
+	// app-operator and chart-operator can't clean up once the workload cluster API is gone
+	"operatorkit.giantswarm.io/app-operator-app",
+	"operatorkit.giantswarm.io/chart-operator-chart",
+	// Flux can't uninstall HelmReleases from a workload cluster that no longer exists
+	"finalizers.fluxcd.io",
+}
+
+// DeleteOptions are the options available when deleting a cluster
+type DeleteOptions struct {
+	ForceAfter                time.Duration
+	SafeFinalizers            []string
+	KeepOrganization          bool
+	IgnoreKeepWorkloadCluster bool
+}
+
+// DeleteOption is a function that can be optionally provided to override default options of a cluster deletion
+type DeleteOption func(*DeleteOptions)
+
+// WithForceDelete enables force deletion. If the Cluster still hasn't been deleted after the provided grace period
+// then the `KnownSafeFinalizers` (and any provided with `WithSafeFinalizers`) are removed from all objects in the
+// cluster's namespace that are pending deletion.
+func WithForceDelete(gracePeriod time.Duration) DeleteOption {
+	return func(options *DeleteOptions) {
+		options.ForceAfter = gracePeriod
+	}
+}
+
+// WithSafeFinalizers adds to the list of finalizers that are removed when force deletion is enabled
+func WithSafeFinalizers(finalizers ...string) DeleteOption {
+	return func(options *DeleteOptions) {
+		options.SafeFinalizers = append(options.SafeFinalizers, finalizers...)
+	}
+}
+
+// WithoutOrgDeletion keeps the cluster's Organization once the cluster has been deleted, e.g. when other clusters in
+// the same Organization are still to be deleted
+func WithoutOrgDeletion() DeleteOption {
+	return func(options *DeleteOptions) {
+		options.KeepOrganization = true
+	}
+}
+
+// WithIgnoreKeepWorkloadCluster deletes the cluster even if the `E2E_WC_KEEP` env var (or `KeepWorkloadCluster`
+// option) is set, e.g. when cleaning up orphaned clusters
+func WithIgnoreKeepWorkloadCluster() DeleteOption {
+	return func(options *DeleteOptions) {
+		options.IgnoreKeepWorkloadCluster = true
+	}
+}
+
+// BlockingObject describes an object still present in a cluster's namespace while the cluster is being deleted
+type BlockingObject struct {
+	APIVersion        string
+	Kind              string
+	Namespace         string
+	Name              string
+	Finalizers        []string
+	OwnerReferences   []string
+	DeletionTimestamp *metav1.Time
+
+	// resourceVersion is the version of the object the finalizers were listed from
+	resourceVersion string
+}
+
+// String returns a single line summary of the object, its finalizers and its owners
+func (o BlockingObject) String() string {
+	summary := fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+	if o.DeletionTimestamp != nil {
+		summary = fmt.Sprintf("%s (deleting since %s)", summary, o.DeletionTimestamp.Format(time.RFC3339))
+	}
+	if len(o.Finalizers) > 0 {
+		summary = fmt.Sprintf("%s finalizers=%v", summary, o.Finalizers)
+	}
+	if len(o.OwnerReferences) > 0 {
+		summary = fmt.Sprintf("%s owners=%v", summary, o.OwnerReferences)
+	}
+	return summary
+}
+
+// DeletionDiagnostics describes what remains in a cluster's namespace while the cluster is being deleted
+type DeletionDiagnostics struct {
+	ClusterName string
+	Namespace   string
+	// ClusterAPIObjects are the remaining CAPI core, control plane, bootstrap and infrastructure objects
+	ClusterAPIObjects []BlockingObject
+	// Objects are all other remaining objects in the namespace
+	Objects []BlockingObject
+}
+
+// String returns a human readable report of the remaining objects
+func (d *DeletionDiagnostics) String() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Remaining objects for Cluster '%s' in namespace '%s':\n", d.ClusterName, d.Namespace)
+	fmt.Fprintf(sb, "  Cluster API objects (%d):\n", len(d.ClusterAPIObjects))
+	for _, obj := range d.ClusterAPIObjects {
+		fmt.Fprintf(sb, "    - %s\n", obj)
+	}
+	fmt.Fprintf(sb, "  Other objects (%d):\n", len(d.Objects))
+	for _, obj := range d.Objects {
+		fmt.Fprintf(sb, "    - %s\n", obj)
+	}
+	return sb.String()
+}
+
+// StuckDeletionError is returned by `DeleteCluster` when the Cluster fails to be deleted in time.
+// It includes diagnostics of what was still present in the cluster's namespace at the time.
+type StuckDeletionError struct {
+	ClusterName string
+	Diagnostics *DeletionDiagnostics
+	Err         error
+}
+
+// Error returns the underlying error along with the diagnostics report, if available
+func (e *StuckDeletionError) Error() string {
+	if e.Diagnostics == nil {
+		return fmt.Sprintf("deletion of Cluster '%s' did not complete: %v", e.ClusterName, e.Err)
+	}
+	return fmt.Sprintf("deletion of Cluster '%s' did not complete: %v\n%s", e.ClusterName, e.Err, e.Diagnostics)
+}
+
+// Unwrap returns the underlying error
+func (e *StuckDeletionError) Unwrap() error {
+	return e.Err
+}
+
+// DiagnoseClusterDeletion returns all objects remaining in the namespace of the provided Cluster, including their
+// finalizers and owner references. This is useful for understanding what is blocking a Cluster from being deleted.
+func (f *Framework) DiagnoseClusterDeletion(ctx context.Context, cluster *application.Cluster) (*DeletionDiagnostics, error) {
+	objects, err := f.MC().ListNamespaceObjects(ctx, cluster.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics := &DeletionDiagnostics{
+		ClusterName: cluster.Name,
+		Namespace:   cluster.GetNamespace(),
+	}
+	for _, obj := range objects {
+		blocking := BlockingObject{
+			APIVersion:        obj.APIVersion,
+			Kind:              obj.Kind,
+			Namespace:         obj.Namespace,
+			Name:              obj.Name,
+			Finalizers:        obj.Finalizers,
+			DeletionTimestamp: obj.DeletionTimestamp,
+			resourceVersion:   obj.ResourceVersion,
+		}
+		for _, owner := range obj.OwnerReferences {
+			blocking.OwnerReferences = append(blocking.OwnerReferences, fmt.Sprintf("%s/%s", owner.Kind, owner.Name))
+		}
+
+		if isClusterAPIGroup(obj.GroupVersionKind().Group) {
+			diagnostics.ClusterAPIObjects = append(diagnostics.ClusterAPIObjects, blocking)
+		} else if !isNamespaceDefault(blocking) {
+			diagnostics.Objects = append(diagnostics.Objects, blocking)
+		}
+	}
+
+	sortBlockingObjects(diagnostics.ClusterAPIObjects)
+	sortBlockingObjects(diagnostics.Objects)
+
+	return diagnostics, nil
+}
+
+// waitForClusterDeletion waits for the CAPI Cluster to be removed, force removing known-safe finalizers after the
+// grace period if enabled. If the Cluster isn't removed in time a StuckDeletionError is returned.
+func (f *Framework) waitForClusterDeletion(ctx context.Context, cluster *application.Cluster, options *DeleteOptions) error {
+	clusterResource := &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name,
+			Namespace: cluster.GetNamespace(),
+		},
+	}
+
+	if options.ForceAfter > 0 {
+		graceCtx, cancel := context.WithTimeout(ctx, options.ForceAfter)
+		err := wait.For(wait.IsResourceDeleted(graceCtx, f.MC(), clusterResource), wait.WithContext(graceCtx))
+		cancel()
+		if err == nil {
+			return nil
+		} else if !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return f.stuckDeletionError(cluster, err)
+		}
+
+		logger.Log("Cluster '%s' still not deleted after %s, removing known-safe finalizers", cluster.Name, options.ForceAfter)
+		isDeleted := wait.IsResourceDeleted(ctx, f.MC(), clusterResource)
+		err = wait.For(func() (bool, error) {
+			deleted, err := isDeleted()
+			if deleted || err != nil {
+				return deleted, err
+			}
+
+			// New objects may have started deleting since the last attempt
+			if err := f.removeSafeFinalizers(ctx, cluster, options.SafeFinalizers); err != nil {
+				logger.Log("Failed to remove finalizers - %v", err)
+			}
+			return false, nil
+		}, wait.WithContext(ctx), wait.WithInterval(30*time.Second))
+		if err != nil {
+			return f.stuckDeletionError(cluster, err)
+		}
+		return nil
+	}
+
+	err := wait.For(wait.IsResourceDeleted(ctx, f.MC(), clusterResource), wait.WithContext(ctx))
+	if err != nil {
+		return f.stuckDeletionError(cluster, err)
+	}
+
+	return nil
+}
+
+// stuckDeletionError gathers diagnostics for the cluster and wraps the provided error in a StuckDeletionError
+func (f *Framework) stuckDeletionError(cluster *application.Cluster, err error) error {
+	// The provided context has likely already expired so we use a new one to gather diagnostics
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsTimeout)
+	defer cancel()
+
+	diagnostics, diagErr := f.DiagnoseClusterDeletion(ctx, cluster)
+	if diagErr != nil {
+		logger.Log("Failed to gather deletion diagnostics for Cluster '%s' - %v", cluster.Name, diagErr)
+	} else {
+		logger.Log("%s", diagnostics)
+	}
+
+	return &StuckDeletionError{
+		ClusterName: cluster.Name,
+		Diagnostics: diagnostics,
+		Err:         err,
+	}
+}
+
+// removeSafeFinalizers removes the known-safe finalizers from all objects pending deletion in the cluster's namespace
+func (f *Framework) removeSafeFinalizers(ctx context.Context, cluster *application.Cluster, safeFinalizers []string) error {
+	diagnostics, err := f.DiagnoseClusterDeletion(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	objects := append(append([]BlockingObject{}, diagnostics.ClusterAPIObjects...), diagnostics.Objects...)
+	for _, obj := range objects {
+		if obj.DeletionTimestamp == nil {
+			continue
+		}
+		if err := f.removeObjectFinalizers(ctx, obj, safeFinalizers); cr.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to remove finalizers from %s %s/%s: %w", obj.Kind, obj.Namespace, obj.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// removeObjectFinalizers removes the known-safe finalizers from the object. The patch is made against the version of
+// the object the finalizers were listed from so that finalizers added since aren't lost, getting the latest version
+// and trying again on conflict.
+func (f *Framework) removeObjectFinalizers(ctx context.Context, obj BlockingObject, safeFinalizers []string) error {
+	target := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: obj.APIVersion, Kind: obj.Kind},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            obj.Name,
+			Namespace:       obj.Namespace,
+			Finalizers:      obj.Finalizers,
+			ResourceVersion: obj.resourceVersion,
+		},
+	}
+
+	attempt := 0
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		attempt++
+		if attempt > 1 {
+			if err := f.MC().Get(ctx, cr.ObjectKeyFromObject(target), target); err != nil {
+				return err
+			}
+		}
+
+		remaining, changed := filterSafeFinalizers(target.Finalizers, safeFinalizers)
+		if !changed {
+			return nil
+		}
+
+		logger.Log("Removing finalizers from %s %s/%s, remaining finalizers: %v", obj.Kind, obj.Namespace, obj.Name, remaining)
+		patch, err := json.Marshal(map[string]any{
+			"metadata": map[string]any{
+				"finalizers":      remaining,
+				"resourceVersion": target.ResourceVersion,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		return f.MC().Patch(ctx, target, cr.RawPatch(types.MergePatchType, patch))
+	})
+}
+
+// filterSafeFinalizers returns the finalizers that aren't safe to remove and whether any were removed
+func filterSafeFinalizers(finalizers []string, safeFinalizers []string) ([]string, bool) {
+	remaining := []string{}
+	for _, finalizer := range finalizers {
+		if !slices.Contains(safeFinalizers, finalizer) {
+			remaining = append(remaining, finalizer)
+		}
+	}
+	return remaining, len(remaining) != len(finalizers)
+}
+
+// isClusterAPIGroup checks if the API group belongs to Cluster API or one of its providers
+func isClusterAPIGroup(group string) bool {
+	return strings.HasSuffix(group, "cluster.x-k8s.io")
+}
+
+// isNamespaceDefault checks if the object is one created automatically in every namespace
+func isNamespaceDefault(obj BlockingObject) bool {
+	return (obj.Kind == "ServiceAccount" && obj.Name == "default") ||
+		(obj.Kind == "ConfigMap" && obj.Name == "kube-root-ca.crt")
+}
+
+func sortBlockingObjects(objects []BlockingObject) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].Kind != objects[j].Kind {
+			return objects[i].Kind < objects[j].Kind
+		}
+		return objects[i].Name < objects[j].Name
+	})
+}
@@ -0,0 +1,149 @@
+package clustertest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+)
+
+func TestFilterSafeFinalizers(t *testing.T) {
+	tests := []struct {
+		name              string
+		finalizers        []string
+		expectedRemaining []string
+		expectedChanged   bool
+	}{
+		{name: "no finalizers", finalizers: nil, expectedRemaining: []string{}, expectedChanged: false},
+		{name: "only unsafe", finalizers: []string{"cluster.cluster.x-k8s.io"}, expectedRemaining: []string{"cluster.cluster.x-k8s.io"}, expectedChanged: false},
+		{name: "only safe", finalizers: []string{"finalizers.fluxcd.io"}, expectedRemaining: []string{}, expectedChanged: true},
+		{
+			name:              "mixed",
+			finalizers:        []string{"operatorkit.giantswarm.io/app-operator-app", "example.com/keep", "finalizers.fluxcd.io"},
+			expectedRemaining: []string{"example.com/keep"},
+			expectedChanged:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			remaining, changed := filterSafeFinalizers(tc.finalizers, KnownSafeFinalizers)
+			if !slices.Equal(remaining, tc.expectedRemaining) {
+				t.Errorf("Remaining finalizers not as expected. Expected: %v, Actual: %v", tc.expectedRemaining, remaining)
+			}
+			if changed != tc.expectedChanged {
+				t.Errorf("Changed not as expected. Expected: %t, Actual: %t", tc.expectedChanged, changed)
+			}
+		})
+	}
+}
+
+func TestStuckDeletionError(t *testing.T) {
+	deletingSince := metav1.NewTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	err := &StuckDeletionError{
+		ClusterName: "t-abc",
+		Err:         context.DeadlineExceeded,
+	}
+
+	if expected := "deletion of Cluster 't-abc' did not complete: context deadline exceeded"; err.Error() != expected {
+		t.Errorf("Error not as expected.\nExpected: %s\nActual: %s", expected, err.Error())
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the underlying error to be unwrapped")
+	}
+
+	err.Diagnostics = &DeletionDiagnostics{
+		ClusterName: "t-abc",
+		Namespace:   "org-test",
+		ClusterAPIObjects: []BlockingObject{
+			{Kind: "AWSCluster", Namespace: "org-test", Name: "t-abc", Finalizers: []string{"awscluster.infrastructure.cluster.x-k8s.io"}, DeletionTimestamp: &deletingSince},
+		},
+		Objects: []BlockingObject{
+			{Kind: "App", Namespace: "org-test", Name: "t-abc-cilium", OwnerReferences: []string{"Cluster/t-abc"}},
+		},
+	}
+	expected := `deletion of Cluster 't-abc' did not complete: context deadline exceeded
+Remaining objects for Cluster 't-abc' in namespace 'org-test':
+  Cluster API objects (1):
+    - AWSCluster org-test/t-abc (deleting since 2026-01-01T12:00:00Z) finalizers=[awscluster.infrastructure.cluster.x-k8s.io]
+  Other objects (1):
+    - App org-test/t-abc-cilium owners=[Cluster/t-abc]
+`
+	if err.Error() != expected {
+		t.Errorf("Error not as expected.\nExpected: %s\nActual: %s", expected, err.Error())
+	}
+}
+
+func TestRemoveObjectFinalizers(t *testing.T) {
+	ctx := context.Background()
+	deletingSince := metav1.Now()
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name:              "example",
+		Namespace:         "org-test",
+		Finalizers:        []string{"finalizers.fluxcd.io", "example.com/keep"},
+		DeletionTimestamp: &deletingSince,
+	}}
+
+	patches := []string{}
+	conflicted := false
+	kubeClient := fake.NewClientBuilder().WithObjects(configMap).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c cr.WithWatch, obj cr.Object, patch cr.Patch, opts ...cr.PatchOption) error {
+			data, _ := patch.Data(obj)
+			patches = append(patches, string(data))
+			if !conflicted {
+				// Simulate the object being changed since it was listed
+				conflicted = true
+				return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("object has been modified"))
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	}).Build()
+	f := &Framework{mcClient: &client.Client{Client: kubeClient}}
+
+	existing := &corev1.ConfigMap{}
+	if err := kubeClient.Get(ctx, cr.ObjectKeyFromObject(configMap), existing); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	err := f.removeObjectFinalizers(ctx, BlockingObject{
+		APIVersion:        "v1",
+		Kind:              "ConfigMap",
+		Namespace:         "org-test",
+		Name:              "example",
+		Finalizers:        existing.Finalizers,
+		DeletionTimestamp: existing.DeletionTimestamp,
+		resourceVersion:   "1",
+	}, KnownSafeFinalizers)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if len(patches) != 2 {
+		t.Fatalf("Expected the patch to be retried after a conflict. Actual patches: %v", patches)
+	}
+	if !strings.Contains(patches[0], `"resourceVersion":"1"`) {
+		t.Errorf("Expected the patch to include the listed resourceVersion. Actual: %s", patches[0])
+	}
+	if !strings.Contains(patches[1], `"resourceVersion":"`+existing.ResourceVersion+`"`) {
+		t.Errorf("Expected the retried patch to include the latest resourceVersion. Actual: %s", patches[1])
+	}
+
+	if err := kubeClient.Get(ctx, cr.ObjectKeyFromObject(configMap), existing); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if !slices.Equal(existing.Finalizers, []string{"example.com/keep"}) {
+		t.Errorf("Expected only the safe finalizers to be removed. Actual: %v", existing.Finalizers)
+	}
+}
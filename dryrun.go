@@ -0,0 +1,68 @@
+package clustertest
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// dryRunOutput is where clusters are rendered to when the Framework is in dry-run mode
+type dryRunOutput struct {
+	mu     sync.Mutex
+	writer io.Writer
+	dir    string
+}
+
+// EnableDryRun switches the Framework into dry-run mode. While enabled, `ApplyCluster`, `ApplyClusters` and
+// `ApplyBuiltCluster` don't make any changes to the MC and instead write the resources that would have been applied to
+// the provided writer as a multi-document YAML stream. As no cluster is created a nil client is returned.
+//
+// Example:
+//
+//	framework.EnableDryRun(os.Stdout)
+//	_, err := framework.ApplyCluster(ctx, cluster)
+func (f *Framework) EnableDryRun(w io.Writer) {
+	f.dryRun = &dryRunOutput{writer: w}
+}
+
+// EnableDryRunToDir is like `EnableDryRun` but writes each resource to its own file within a subdirectory (named after
+// the cluster) of the provided directory.
+//
+// Example:
+//
+//	framework.EnableDryRunToDir("./manifests")
+//	_, err := framework.ApplyCluster(ctx, cluster)
+func (f *Framework) EnableDryRunToDir(dir string) {
+	f.dryRun = &dryRunOutput{dir: dir}
+}
+
+// IsDryRun returns true if the Framework is in dry-run mode
+func (f *Framework) IsDryRun() bool {
+	return f.dryRun != nil
+}
+
+// renderBuiltCluster writes the resources of the BuiltCluster to the configured dry-run output
+func (f *Framework) renderBuiltCluster(builtCluster *application.BuiltCluster) error {
+	f.dryRun.mu.Lock()
+	defer f.dryRun.mu.Unlock()
+
+	if f.dryRun.dir != "" {
+		dir := filepath.Join(f.dryRun.dir, builtCluster.SourceCluster.Name)
+		logger.Log("[dry-run] Writing Cluster '%s' manifests to '%s'", builtCluster.SourceCluster.Name, dir)
+		return builtCluster.WriteToDir(dir)
+	}
+
+	logger.Log("[dry-run] Rendering Cluster '%s' manifests", builtCluster.SourceCluster.Name)
+	if _, err := fmt.Fprintf(f.dryRun.writer, "# Cluster: %s/%s\n", builtCluster.SourceCluster.GetNamespace(), builtCluster.SourceCluster.Name); err != nil {
+		return err
+	}
+	if err := builtCluster.WriteYAML(f.dryRun.writer); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(f.dryRun.writer, "---")
+	return err
+}
@@ -0,0 +1,81 @@
+package clustertest
+
+import (
+	"github.com/giantswarm/clustertest/v5/pkg/events"
+)
+
+// EnableEventRecording configures the Framework to continuously record Events, from the moment a cluster is applied or
+// loaded, from both the cluster's organization namespace on the MC and all namespaces of the WC. Recorded Events remain
+// available, via the returned Recorder, after they have aged out of the cluster.
+//
+// Clusters already known to the Framework start being recorded immediately. By default only Warning events are
+// recorded, see the `events` package for the available options.
+//
+// Call `Stop` on the returned Recorder once finished to stop watching and write any remaining Events to the artifact
+// file, if configured.
+//
+// Example:
+//
+//	recorder := framework.EnableEventRecording(events.WithArtifactFile("/tmp/artifacts/events.json"))
+//	defer recorder.Stop()
+//
+//	// Later, in a failure handler
+//	for _, event := range recorder.Warnings("Pod", testStart) {
+//		logger.Log("%s %s/%s: %s", event.Reason, event.Namespace, event.Name, event.Message)
+//	}
+func (f *Framework) EnableEventRecording(opts ...events.Option) *events.Recorder {
+	f.wcClientsMu.Lock()
+	defer f.wcClientsMu.Unlock()
+
+	if f.events != nil {
+		return f.events
+	}
+	f.events = events.NewRecorder(opts...)
+
+	for clusterName, wcClient := range f.wcClients {
+		f.events.Watch(wcClient, clusterName, "")
+	}
+
+	f.registry.mu.RLock()
+	defer f.registry.mu.RUnlock()
+	for _, clusterState := range f.registry.clusters {
+		f.events.Watch(f.MC(), f.MC().GetClusterName(), clusterState.Cluster.GetNamespace())
+	}
+
+	return f.events
+}
+
+// EventRecorder returns the Recorder set up by `EnableEventRecording` or nil if event recording isn't enabled
+func (f *Framework) EventRecorder() *events.Recorder {
+	f.wcClientsMu.RLock()
+	defer f.wcClientsMu.RUnlock()
+	return f.events
+}
+
+// recordOrgEvents starts recording Events from the given organization namespace on the MC if event recording is enabled
+func (f *Framework) recordOrgEvents(namespace string) {
+	recorder := f.EventRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.Watch(f.MC(), f.MC().GetClusterName(), namespace)
+}
+
+// stopRecordingWCEvents stops recording Events from the given WC, if event recording is enabled
+func (f *Framework) stopRecordingWCEvents(clusterName string) {
+	recorder := f.EventRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.Unwatch(clusterName, "")
+}
+
+// stopRecordingOrgEvents stops recording Events from the given organization namespace on the MC, if event recording is
+// enabled
+func (f *Framework) stopRecordingOrgEvents(namespace string) {
+	recorder := f.EventRecorder()
+	if recorder == nil {
+		return
+	}
+	recorder.Unwatch(f.MC().GetClusterName(), namespace)
+}
@@ -4,20 +4,23 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/giantswarm/clustertest/v5/pkg/application"
 	"github.com/giantswarm/clustertest/v5/pkg/client"
 	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/events"
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
 	"github.com/giantswarm/clustertest/v5/pkg/organization"
 	"github.com/giantswarm/clustertest/v5/pkg/testuser"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
 	"github.com/giantswarm/clustertest/v5/pkg/utils"
 	"github.com/giantswarm/clustertest/v5/pkg/wait"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
 	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
@@ -35,25 +38,25 @@ type Framework struct {
 	mcKubeconfigPath string
 	mcClient         *client.Client
 	wcClients        map[string]*client.Client
+	wcClientsMu      sync.RWMutex
+	tracker          *resourceTracker
+	timings          *timing.Recorder
+	events           *events.Recorder
+	registry         stateRegistry
+	dryRun           *dryRunOutput
+	options          Options
+}
+
+// ClusterResult contains the outcome of applying a single Cluster as part of ApplyClusters
+type ClusterResult struct {
+	Cluster *application.Cluster
+	Client  *client.Client
+	Err     error
 }
 
 // New initializes a new Framework instance using the provided context from the kubeconfig found in the env var `E2E_KUBECONFIG`
 func New(contextName string) (*Framework, error) {
-	mcKubeconfig, ok := os.LookupEnv(env.Kubeconfig)
-	if !ok {
-		return nil, fmt.Errorf("no %s set", env.Kubeconfig)
-	}
-
-	mcClient, err := client.NewWithContext(mcKubeconfig, contextName)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Framework{
-		mcKubeconfigPath: mcKubeconfig,
-		mcClient:         mcClient,
-		wcClients:        map[string]*client.Client{},
-	}, nil
+	return NewWithOptions(Options{Context: contextName})
 }
 
 // MC returns an initialized client for the Management Cluster
@@ -61,10 +64,22 @@ func (f *Framework) MC() *client.Client {
 	return f.mcClient
 }
 
+// PhaseTimings returns the Recorder holding how long each phase of `ApplyBuiltCluster` (including via `ApplyCluster`)
+// and `DeleteCluster` took for each cluster. These can be exported with `WriteJSON` or `WriteJUnit`.
+//
+// Example:
+//
+//	err := framework.PhaseTimings().WriteJSON(os.Stdout)
+func (f *Framework) PhaseTimings() *timing.Recorder {
+	return f.timings
+}
+
 // WC returns an initialized client for the Workload Cluster matching the given name.
 // If no Workload Cluster is found matching the given name an error is returned.
 func (f *Framework) WC(clusterName string) (*client.Client, error) {
+	f.wcClientsMu.RLock()
 	c, ok := f.wcClients[clusterName]
+	f.wcClientsMu.RUnlock()
 	if !ok {
 		if clusterName == f.MC().GetClusterName() {
 			// Looks like we're actually attempting to get the MC, not a WC so we'll return the MC client
@@ -76,14 +91,30 @@ func (f *Framework) WC(clusterName string) (*client.Client, error) {
 	return c, nil
 }
 
+// setWC stores the client for the Workload Cluster matching the given name.
+// It is safe to call from multiple goroutines.
+func (f *Framework) setWC(clusterName string, c *client.Client) {
+	f.wcClientsMu.Lock()
+	defer f.wcClientsMu.Unlock()
+	if f.tracker != nil {
+		c.AddCreateHook(f.trackObjectHook(clusterName))
+	}
+	if f.events != nil {
+		f.events.Watch(c, clusterName, "")
+	}
+	f.wcClients[clusterName] = c
+}
+
 // LoadCluster will construct a Cluster struct using a Workload Cluster's
-// cluster App CR on the targeted Management Cluster. The name and namespace
+// cluster App CR (or HelmRelease) on the targeted Management Cluster. The name and namespace
 // where the cluster is installed need to be provided with the E2E_WC_NAME
 // and E2E_WC_NAMESPACE env vars.
 //
 // If one of the env vars are not set, a nil Cluster and nil error will be
 // returned.
 //
+// See `LoadClusterByName` for details on what is loaded.
+//
 // Example:
 //
 //	cluster, err := framework.LoadCluster()
@@ -94,49 +125,72 @@ func (f *Framework) WC(clusterName string) (*client.Client, error) {
 //		// handle cluster not provided
 //	}
 func (f *Framework) LoadCluster() (*application.Cluster, error) {
-	ctx := context.Background()
 	name := os.Getenv(env.WorkloadClusterName)
 	namespace := os.Getenv(env.WorkloadClusterNamespace)
-	org := organization.NewFromNamespace(namespace)
 
 	if name == "" || namespace == "" {
 		return nil, nil
 	}
 
-	clusterApp, clusterValues, err := f.GetAppAndValues(ctx, name, namespace)
+	return f.LoadClusterByName(context.Background(), name, namespace)
+}
+
+// LoadClusterByName will construct a Cluster struct for an existing Workload Cluster with the given name and namespace
+// on the targeted Management Cluster and set up a client for it, available via `WC`.
+//
+// The cluster chart may be delivered either as an App CR with a userconfig ConfigMap or as a Flux HelmRelease.
+// Clusters delivered as a HelmRelease can't be deleted or upgraded with the Framework.
+//
+// If the cluster values specify a release version (`global.release.version`) the matching Release CR is fetched
+// from the MC and used to populate `Cluster.Release`. If the Release CR doesn't exist, or the cluster's provider is
+// unknown, `Cluster.Release` is left empty.
+//
+// Example:
+//
+//	cluster, err := framework.LoadClusterByName(ctx, "t-abcdef", "org-t-abcdef")
+func (f *Framework) LoadClusterByName(ctx context.Context, name string, namespace string) (*application.Cluster, error) {
+	org := organization.NewFromNamespace(namespace)
+
+	clusterApp, err := f.getClusterApplication(ctx, name, namespace)
 	if err != nil {
 		return nil, err
 	}
+	clusterApp.Organization = *org
 
-	kubeconfig, err := f.mcClient.GetClusterKubeConfig(context.Background(), name, namespace)
-	if err != nil {
+	cluster := &application.Cluster{
+		Name:         name,
+		ClusterApp:   clusterApp,
+		Organization: org,
+		Provider:     application.ProviderFromClusterAppName(clusterApp.AppName),
+	}
+
+	clusterState := ClusterState{Cluster: cluster}
+	release, err := f.GetClusterRelease(ctx, cluster)
+	if errors.IsNotFound(err) {
+		logger.Log("Release of cluster '%s' not found, continuing without it - %v", name, err)
+	} else if err != nil {
 		return nil, err
 	}
+	if release != nil {
+		cluster.Release = releasePairFromRelease(release)
+		clusterState.ReleaseName = release.Name
+	}
 
-	wcClient, err := client.NewFromRawKubeconfig(string(kubeconfig))
+	kubeconfig, err := f.mcClient.GetClusterKubeConfig(ctx, name, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	f.wcClients[name] = wcClient
-
-	cluster := &application.Cluster{
-		Name: name,
-		ClusterApp: &application.Application{
-			InstallName:     clusterApp.Name,
-			AppName:         clusterApp.Spec.Name,
-			Version:         clusterApp.Spec.Version,
-			Catalog:         clusterApp.Spec.Catalog,
-			Values:          clusterValues.Data["values"],
-			InCluster:       clusterApp.Spec.KubeConfig.InCluster,
-			Organization:    *org,
-			AppLabels:       clusterApp.Labels,
-			ConfigMapLabels: clusterValues.Labels,
-		},
-		Organization: org,
-		Provider:     application.ProviderFromClusterApplication(clusterApp),
+	wcClient, err := client.NewFromRawKubeconfigWithOptions([]byte(kubeconfig), f.clientOptions())
+	if err != nil {
+		return nil, err
 	}
 
+	f.enableCredentialRefresh(wcClient, name, namespace)
+	f.recordOrgEvents(namespace)
+	f.setWC(name, wcClient)
+	f.registry.addCluster(clusterState)
+
 	return cluster, nil
 }
 
@@ -153,6 +207,8 @@ func (f *Framework) LoadCluster() (*application.Cluster, error) {
 //
 //	client, err := framework.ApplyCluster(timeoutCtx, cluster)
 func (f *Framework) ApplyCluster(ctx context.Context, cluster *application.Cluster) (*client.Client, error) {
+	f.applyClusterDefaults(cluster)
+
 	builtCluster, err := cluster.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build cluster app: %w", err)
@@ -161,6 +217,60 @@ func (f *Framework) ApplyCluster(ctx context.Context, cluster *application.Clust
 	return f.ApplyBuiltCluster(ctx, builtCluster)
 }
 
+// ApplyClusters takes multiple Cluster objects and builds and applies them all concurrently, waiting until all of them
+// have finished (either successfully or with an error).
+//
+// The returned results are in the same order as the provided clusters and contain either the client for the Workload
+// Cluster or the error encountered for that Cluster. If any of the clusters failed an aggregated error is also returned.
+//
+// A timeout can be provided via the given `ctx` value by using `context.WithTimeout()`
+//
+// Example:
+//
+//	timeoutCtx, cancelTimeout := context.WithTimeout(context.Background(), 20*time.Minute)
+//	defer cancelTimeout()
+//
+//	clusterA := application.NewClusterApp(utils.GenerateRandomName("t"), application.ProviderAWS)
+//	clusterB := application.NewClusterApp(utils.GenerateRandomName("t"), application.ProviderAWS)
+//
+//	results, err := framework.ApplyClusters(timeoutCtx, clusterA, clusterB)
+func (f *Framework) ApplyClusters(ctx context.Context, clusters ...*application.Cluster) ([]ClusterResult, error) {
+	return applyConcurrently(ctx, f.ApplyCluster, clusters)
+}
+
+// applyConcurrently calls applyFn for each of the provided clusters concurrently and collects the results
+func applyConcurrently(ctx context.Context, applyFn func(context.Context, *application.Cluster) (*client.Client, error), clusters []*application.Cluster) ([]ClusterResult, error) {
+	results := make([]ClusterResult, len(clusters))
+
+	var wg sync.WaitGroup
+	for i, cluster := range clusters {
+		wg.Add(1)
+		go func(i int, cluster *application.Cluster) {
+			defer wg.Done()
+
+			wcClient, err := applyFn(ctx, cluster)
+			if err != nil {
+				err = fmt.Errorf("cluster '%s': %w", cluster.Name, err)
+			}
+			results[i] = ClusterResult{
+				Cluster: cluster,
+				Client:  wcClient,
+				Err:     err,
+			}
+		}(i, cluster)
+	}
+	wg.Wait()
+
+	errs := []error{}
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return results, utilerrors.NewAggregate(errs)
+}
+
 // ApplyBuiltCluster takes a pre-built Cluster object, applies it to the MC in the correct order and then waits for a valid Kubeconfig to be available
 //
 // A timeout can be provided via the given `ctx` value by using `context.WithTimeout()`
@@ -173,45 +283,131 @@ func (f *Framework) ApplyCluster(ctx context.Context, cluster *application.Clust
 //	cluster := application.NewClusterApp(utils.GenerateRandomName("t"), application.ProviderAWS)
 //	builtCluster, _ := cluster.Build()
 //	client, err := framework.ApplyBuiltCluster(timeoutCtx, builtCluster)
+//
+// If the Framework is in dry-run mode (see `EnableDryRun`) the resources are only rendered and a nil client is returned.
+//
+// The time taken by each phase (e.g. creating the Release, waiting for the kubeconfig) is recorded, see `PhaseTimings`.
 func (f *Framework) ApplyBuiltCluster(ctx context.Context, builtCluster *application.BuiltCluster) (*client.Client, error) {
+	if f.IsDryRun() {
+		if err := f.renderBuiltCluster(builtCluster); err != nil {
+			return nil, fmt.Errorf("failed to render cluster resources: %w", err)
+		}
+		return nil, nil
+	}
+
+	clusterName := builtCluster.SourceCluster.Name
+	var testClient *client.Client
+	err := f.timings.Time(clusterName, timing.OperationApply, timing.PhaseTotal, func() (err error) {
+		testClient, err = f.applyBuiltCluster(ctx, builtCluster)
+		return err
+	})
+	return testClient, err
+}
+
+// applyBuiltCluster applies the resources of the BuiltCluster to the MC and waits for the cluster to be ready,
+// recording the time taken for each phase
+func (f *Framework) applyBuiltCluster(ctx context.Context, builtCluster *application.BuiltCluster) (*client.Client, error) {
+	clusterName := builtCluster.SourceCluster.Name
+	clusterNamespace := builtCluster.SourceCluster.GetNamespace()
+	timePhase := func(phase string, fn func() error) error {
+		return f.timings.Time(clusterName, timing.OperationApply, phase, fn)
+	}
+
 	if builtCluster.Release != nil {
-		if err := f.MC().CreateOrUpdate(ctx, builtCluster.Release); err != nil {
+		err := timePhase(timing.PhaseRelease, func() error {
+			return f.MC().CreateOrUpdate(ctx, builtCluster.Release)
+		})
+		if err != nil {
 			return nil, fmt.Errorf("failed to apply release resources: %w", err)
 		}
 	}
 
-	err := f.CreateOrg(ctx, builtCluster.SourceCluster.Organization)
+	err := timePhase(timing.PhaseOrganization, func() error {
+		return f.CreateOrg(ctx, builtCluster.SourceCluster.Organization)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create organization: %w", err)
 	}
+	f.recordOrgEvents(clusterNamespace)
 
 	// Apply Cluster resources
-	if err := f.MC().DeployAppManifests(ctx, builtCluster.Cluster.App, builtCluster.Cluster.ConfigMap); err != nil {
+	err = timePhase(timing.PhaseClusterApp, func() error {
+		return f.MC().DeployAppManifests(ctx, builtCluster.Cluster.App, builtCluster.Cluster.ConfigMap)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to apply cluster resources: %w", err)
 	}
+	f.trackCluster(builtCluster.SourceCluster)
+	clusterState := ClusterState{Cluster: builtCluster.SourceCluster}
+	if builtCluster.Release != nil {
+		clusterState.ReleaseName = builtCluster.Release.Name
+	}
+	f.registry.addCluster(clusterState)
 
-	kubeClient, err := f.WaitForClusterReady(ctx, builtCluster.SourceCluster.Name, builtCluster.SourceCluster.GetNamespace())
+	err = timePhase(timing.PhaseKubeconfig, func() error {
+		return f.waitForKubeconfig(ctx, clusterName, clusterNamespace)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to wait until cluster is ready: %w", err)
 	}
 
-	testClient := kubeClient
-	// Do not switch to using test user if the kubeconfig is from Teleport
-	if !kubeClient.IsTeleportKubeconfig() {
-		// Create the E2E test service account and create a new client authenticated as it
-		logger.Log("KubeConfig isn't managed by Teleport, generating ServiceAccount in WC to assume")
-		testClient, err = testuser.Create(ctx, kubeClient)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create test user: %w", err)
-		}
+	var kubeClient *client.Client
+	err = timePhase(timing.PhaseAPIReachable, func() (err error) {
+		kubeClient, err = f.WaitForClusterReady(ctx, clusterName, clusterNamespace)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until cluster is ready: %w", err)
+	}
+
+	var testClient *client.Client
+	err = timePhase(timing.PhaseTestUser, func() (err error) {
+		testClient, err = f.testUserClient(ctx, kubeClient)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create test user: %w", err)
+	}
+	if testClient == kubeClient {
+		f.enableCredentialRefresh(testClient, clusterName, clusterNamespace)
 	}
 
 	// Store the WC client for use in the tests
-	f.wcClients[builtCluster.SourceCluster.Name] = testClient
+	f.setWC(clusterName, testClient)
 
 	return testClient, nil
 }
 
+// waitForKubeconfig waits until the kubeconfig of the cluster is available from the MC
+func (f *Framework) waitForKubeconfig(ctx context.Context, clusterName string, namespace string) error {
+	return wait.For(func() (bool, error) {
+		if _, err := f.MC().GetClusterKubeConfig(ctx, clusterName, namespace); err != nil {
+			logger.Log("Kubeconfig for cluster '%s' not yet available: %v", clusterName, err)
+			return false, nil
+		}
+		return true, nil
+	}, wait.WithContext(ctx), wait.WithInterval(10*time.Second))
+}
+
+// testUserClient returns a client for the Workload Cluster authenticated as the E2E test user, creating the user if
+// needed. If the provided client's kubeconfig is from Teleport it is returned as is.
+func (f *Framework) testUserClient(ctx context.Context, kubeClient *client.Client) (*client.Client, error) {
+	// Do not switch to using test user if the kubeconfig is from Teleport
+	if kubeClient.IsTeleportKubeconfig() {
+		return kubeClient, nil
+	}
+
+	// Create the E2E test service account and create a new client authenticated as it
+	logger.Log("KubeConfig isn't managed by Teleport, generating ServiceAccount in WC to assume")
+	return testuser.Create(ctx, kubeClient)
+}
+
+// enableCredentialRefresh configures a Workload Cluster client created from the cluster's kubeconfig to fetch a new
+// kubeconfig from the MC whenever its credentials expire, such as with short-lived Teleport certificates
+func (f *Framework) enableCredentialRefresh(wcClient *client.Client, clusterName string, clusterNamespace string) {
+	wcClient.SetRefreshFunc(client.KubeconfigRefreshFunc(f.MC(), clusterName, clusterNamespace))
+}
+
 // WaitForClusterReady watches for a Kubeconfig secret to be created on the MC and then waits until that cluster's api-server response successfully
 //
 // A timeout can be provided via the given `ctx` value by using `context.WithTimeout()`
@@ -253,9 +449,33 @@ func (f *Framework) WaitForControlPlane(ctx context.Context, c *client.Client, e
 }
 
 // DeleteCluster removes the Cluster app from the MC
-func (f *Framework) DeleteCluster(ctx context.Context, cluster *application.Cluster) error {
-	keep := strings.ToLower(os.Getenv(env.KeepWorkloadCluster))
-	if keep != "" && keep != "false" {
+//
+// Clusters deployed as a Flux HelmRelease (e.g. loaded with `LoadClusterByName`) aren't supported and an error is
+// returned for them.
+//
+// If the Cluster fails to be deleted before the provided `ctx` is done a `*StuckDeletionError` is returned that
+// details the objects still remaining in the cluster's namespace along with their finalizers and owners.
+//
+// Force deletion can be enabled with `WithForceDelete` to remove known-safe finalizers after a grace period.
+//
+// The time taken by each deletion step is recorded, see `PhaseTimings`.
+//
+// Example:
+//
+//	err := framework.DeleteCluster(ctx, cluster, clustertest.WithForceDelete(20*time.Minute))
+//	var stuckErr *clustertest.StuckDeletionError
+//	if errors.As(err, &stuckErr) {
+//		fmt.Println(stuckErr.Diagnostics)
+//	}
+func (f *Framework) DeleteCluster(ctx context.Context, cluster *application.Cluster, opts ...DeleteOption) error {
+	options := &DeleteOptions{
+		SafeFinalizers: append([]string{}, KnownSafeFinalizers...),
+	}
+	for _, optFn := range opts {
+		optFn(options)
+	}
+
+	if f.shouldKeepWorkloadCluster() && !options.IgnoreKeepWorkloadCluster {
 		logger.Log("⚠️ The %s env var is set, skipping deletion of workload cluster", env.KeepWorkloadCluster)
 		logger.Log("⚠️ This means the Cluster '%s' will remain on the management cluster only until the cluster-cleaner decides to remove it later. To disable the cluster-cleaner behavior please manually add the 'alpha.giantswarm.io/ignore-cluster-deletion' annotation to your test cluster.", cluster.Name)
 		logger.Log("⚠️ Please be sure to manually delete the '%s' Organisation and any associated Releases when you are finished.", cluster.Organization.Name)
@@ -267,59 +487,96 @@ func (f *Framework) DeleteCluster(ctx context.Context, cluster *application.Clus
 		logger.Log("⚠️ The workload cluster is being deleted. If you wanted to reuse this cluster please make sure to set the '%s' env var in the future to skip deletion.", env.KeepWorkloadCluster)
 	}
 
-	app := applicationv1alpha1.App{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name,
-			Namespace: cluster.GetNamespace(),
-		},
+	return f.timings.Time(cluster.Name, timing.OperationDelete, timing.PhaseTotal, func() error {
+		return f.deleteCluster(ctx, cluster, options)
+	})
+}
+
+// deleteCluster removes the Cluster app and all associated resources from the MC, recording the time taken for each
+// phase
+func (f *Framework) deleteCluster(ctx context.Context, cluster *application.Cluster, options *DeleteOptions) error {
+	timePhase := func(phase string, fn func() error) error {
+		return f.timings.Time(cluster.Name, timing.OperationDelete, phase, fn)
 	}
-	err := f.MC().Delete(ctx, &app)
+
+	err := timePhase(timing.PhaseClusterAppDeletion, func() error {
+		if err := f.checkClusterIsApp(ctx, cluster); err != nil {
+			return err
+		}
+
+		app := applicationv1alpha1.App{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cluster.Name,
+				Namespace: cluster.GetNamespace(),
+			},
+		}
+		// The App may already have been removed, e.g. by a previous attempt that failed at a later step
+		return cr.IgnoreNotFound(f.MC().Delete(ctx, &app))
+	})
 	if err != nil {
 		return err
 	}
 
-	clusterResource := &capi.Cluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cluster.Name,
-			Namespace: cluster.GetNamespace(),
-		},
-	}
-	err = wait.For(wait.IsResourceDeleted(ctx, f.MC(), clusterResource), wait.WithContext(ctx))
+	err = timePhase(timing.PhaseClusterDeletion, func() error {
+		return f.waitForClusterDeletion(ctx, cluster, options)
+	})
 	if err != nil {
 		return err
 	}
+	// The WC API is no longer available once the Cluster is gone
+	f.stopRecordingWCEvents(cluster.Name)
 
 	// Remove the finalizer from the bastion secret (if it exists) or the namespace delete gets blocked
-	err = f.MC().Patch(ctx,
-		&corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-bastion-ignition", cluster.Name),
-				Namespace: cluster.GetNamespace(),
+	err = timePhase(timing.PhaseBastionSecretCleanup, func() error {
+		err := f.MC().Patch(ctx,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-bastion-ignition", cluster.Name),
+					Namespace: cluster.GetNamespace(),
+				},
 			},
-		},
-		cr.RawPatch(types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`)),
-	)
-	if cr.IgnoreNotFound(err) != nil {
+			cr.RawPatch(types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`)),
+		)
+		return cr.IgnoreNotFound(err)
+	})
+	if err != nil {
 		return err
 	}
 
 	// Clean up any releases associated with this test Cluster
-	releaseList := releases.ReleaseList{}
-	err = f.MC().List(ctx, &releaseList, &cr.MatchingLabels{"giantswarm.io/cluster": cluster.Name})
-	if cr.IgnoreNotFound(err) != nil {
+	err = timePhase(timing.PhaseReleaseDeletion, func() error {
+		releaseList := releases.ReleaseList{}
+		err := f.MC().List(ctx, &releaseList, &cr.MatchingLabels{"giantswarm.io/cluster": cluster.Name})
+		if cr.IgnoreNotFound(err) != nil {
+			return err
+		}
+		for i := range releaseList.Items {
+			if utils.SafeToDelete(releaseList.Items[i].GetAnnotations()) {
+				logger.Log("Deleting Release '%s'", releaseList.Items[i].Name)
+				err = f.MC().Delete(ctx, &releaseList.Items[i])
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	for i := range releaseList.Items {
-		if utils.SafeToDelete(releaseList.Items[i].GetAnnotations()) {
-			logger.Log("Deleting Release '%s'", releaseList.Items[i].Name)
-			err = f.MC().Delete(ctx, &releaseList.Items[i])
-			if err != nil {
-				return err
-			}
+
+	if !options.KeepOrganization {
+		err = timePhase(timing.PhaseOrganizationDeletion, func() error {
+			return f.DeleteOrg(ctx, cluster.Organization)
+		})
+		if err != nil {
+			return err
 		}
+		f.stopRecordingOrgEvents(cluster.GetNamespace())
 	}
 
-	return f.DeleteOrg(ctx, cluster.Organization)
+	f.registry.removeCluster(cluster.Name)
+	return nil
 }
 
 // CreateOrg create a new Organization in the MC (which then triggers the creation of the org namespace)
@@ -335,10 +592,12 @@ func (f *Framework) CreateOrg(ctx context.Context, org *organization.Org) error
 	} else if err != nil {
 		// Not found so lets create
 		err = f.MC().Create(ctx, orgCR, &cr.CreateOptions{})
-		if err != nil {
+		// Already existing means it was created concurrently, e.g. by another cluster in `ApplyClusters`
+		if err != nil && !errors.IsAlreadyExists(err) {
 			return err
 		}
 	}
+	f.registry.addOrg(org)
 
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -360,6 +619,7 @@ func (f *Framework) DeleteOrg(ctx context.Context, org *organization.Org) error
 		return err
 	} else if err != nil {
 		// Not found, nothing for us to do
+		f.registry.removeOrg(org.Name)
 		return nil
 	}
 
@@ -375,6 +635,7 @@ func (f *Framework) DeleteOrg(ctx context.Context, org *organization.Org) error
 		}
 	}
 
+	f.registry.removeOrg(org.Name)
 	return nil
 }
 
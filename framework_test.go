@@ -0,0 +1,107 @@
+package clustertest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	orgv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
+)
+
+func TestApplyClusters_SharedOrg(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, orgv1alpha1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("Failed to build scheme - %v", err)
+		}
+	}
+
+	org := organization.New("shared")
+	clusters := []*application.Cluster{
+		{Name: "t-one", Organization: org},
+		{Name: "t-two", Organization: org},
+		{Name: "t-three", Organization: org},
+	}
+
+	// Hold every lookup of the Organization until all clusters have checked for it so they all attempt to create it
+	var lookups sync.WaitGroup
+	lookups.Add(len(clusters))
+	kubeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: org.GetNamespace()}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c cr.WithWatch, key cr.ObjectKey, obj cr.Object, opts ...cr.GetOption) error {
+				err := c.Get(ctx, key, obj, opts...)
+				if _, ok := obj.(*orgv1alpha1.Organization); ok {
+					lookups.Done()
+					lookups.Wait()
+				}
+				return err
+			},
+		}).
+		Build()
+	f := &Framework{
+		mcClient:  &client.Client{Client: kubeClient},
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+
+	wcClients := map[string]*client.Client{}
+	for _, cluster := range clusters {
+		wcClients[cluster.Name] = &client.Client{}
+	}
+	results, err := applyConcurrently(ctx, func(ctx context.Context, cluster *application.Cluster) (*client.Client, error) {
+		if err := f.CreateOrg(ctx, cluster.Organization); err != nil {
+			return nil, err
+		}
+		if cluster.Name == "t-two" {
+			return nil, errors.New("cluster app failed")
+		}
+		return wcClients[cluster.Name], nil
+	}, clusters)
+
+	if err == nil || err.Error() != "cluster 't-two': cluster app failed" {
+		t.Errorf("Expected only the failed cluster to be returned in the aggregated error. Actual: %v", err)
+	}
+	if len(results) != len(clusters) {
+		t.Fatalf("Expected a result for each cluster. Actual: %d", len(results))
+	}
+	for i, result := range results {
+		if result.Cluster != clusters[i] {
+			t.Errorf("Expected result %d to be for cluster '%s'. Actual: '%s'", i, clusters[i].Name, result.Cluster.Name)
+		}
+		if result.Cluster.Name == "t-two" {
+			if result.Err == nil || !strings.Contains(result.Err.Error(), "cluster app failed") || result.Client != nil {
+				t.Errorf("Expected the failed cluster to have an error and no client. Actual: %+v", result)
+			}
+			continue
+		}
+		if result.Err != nil || result.Client != wcClients[result.Cluster.Name] {
+			t.Errorf("Expected cluster '%s' to succeed with its client. Actual: %+v", result.Cluster.Name, result)
+		}
+	}
+
+	orgList := &orgv1alpha1.OrganizationList{}
+	if err := kubeClient.List(ctx, orgList); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(orgList.Items) != 1 {
+		t.Errorf("Expected the shared Organization to be created once. Actual: %d", len(orgList.Items))
+	}
+}
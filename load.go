@@ -0,0 +1,220 @@
+package clustertest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
+)
+
+// defaultHelmReleaseValuesKey is the key used for HelmRelease valuesFrom references that don't specify one
+const defaultHelmReleaseValuesKey = "values.yaml"
+
+// getClusterApplication returns the cluster chart of an existing cluster as an Application, read from either the
+// cluster App CR and its userconfig ConfigMap or, if no App CR is found, the cluster HelmRelease
+func (f *Framework) getClusterApplication(ctx context.Context, name string, namespace string) (*application.Application, error) {
+	clusterApp, err := f.GetApp(ctx, name, namespace)
+	if err == nil {
+		logger.Log("Cluster '%s' is deployed as an App", name)
+		clusterValues, err := f.GetConfigMap(ctx, clusterApp.Spec.UserConfig.ConfigMap.Name, clusterApp.Spec.UserConfig.ConfigMap.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		return &application.Application{
+			InstallName:     clusterApp.Name,
+			AppName:         clusterApp.Spec.Name,
+			RepoName:        clusterApp.Spec.Name,
+			Version:         clusterApp.Spec.Version,
+			Catalog:         clusterApp.Spec.Catalog,
+			Values:          clusterValues.Data["values"],
+			InCluster:       clusterApp.Spec.KubeConfig.InCluster,
+			AppLabels:       clusterApp.Labels,
+			ConfigMapLabels: clusterValues.Labels,
+		}, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	helmRelease, values, err := f.GetHelmReleaseAndValues(ctx, name, namespace)
+	if errors.IsNotFound(err) {
+		return nil, fmt.Errorf("no cluster App or HelmRelease found for cluster '%s/%s'", namespace, name)
+	} else if err != nil {
+		return nil, err
+	}
+	logger.Log("Cluster '%s' is deployed as a HelmRelease", name)
+
+	chartApp := &application.Application{
+		InstallName: helmRelease.Name,
+		InCluster:   true,
+		Values:      values,
+		AppLabels:   helmRelease.Labels,
+	}
+	if helmRelease.Spec.Chart != nil {
+		chartApp.AppName = helmRelease.Spec.Chart.Spec.Chart
+		chartApp.Version = helmRelease.Spec.Chart.Spec.Version
+		chartApp.Catalog = helmRelease.Spec.Chart.Spec.SourceRef.Name
+	}
+	// Charts referenced with `chartRef`, or with a semver range as their version, only have their exact details
+	// available from the deployed release
+	if latest := helmRelease.Status.History.Latest(); latest != nil {
+		if chartApp.AppName == "" {
+			chartApp.AppName = latest.ChartName
+		}
+		if chartApp.Version == "" || strings.ContainsAny(chartApp.Version, "*<>=~^ ") {
+			chartApp.Version = latest.ChartVersion
+		}
+	}
+	if chartApp.Catalog == "" && helmRelease.Spec.ChartRef != nil {
+		chartApp.Catalog = helmRelease.Spec.ChartRef.Name
+	}
+	chartApp.RepoName = chartApp.AppName
+
+	return chartApp, nil
+}
+
+// GetHelmReleaseAndValues will return the specified HelmRelease from the Management Cluster along with its values,
+// merged in the same order as Flux does: each `valuesFrom` ConfigMap or Secret in turn followed by the inline `values`.
+func (f *Framework) GetHelmReleaseAndValues(ctx context.Context, name, namespace string) (*helmv2.HelmRelease, string, error) {
+	helmRelease := &helmv2.HelmRelease{}
+	err := f.mcClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, helmRelease)
+	if err != nil {
+		return nil, "", err
+	}
+
+	layers := []string{}
+	for _, ref := range helmRelease.Spec.ValuesFrom {
+		if ref.TargetPath != "" {
+			logger.Log("Skipping HelmRelease values from %s '%s' as targetPath is not supported", ref.Kind, ref.Name)
+			continue
+		}
+
+		valuesKey := ref.ValuesKey
+		if valuesKey == "" {
+			valuesKey = defaultHelmReleaseValuesKey
+		}
+
+		var values string
+		key := types.NamespacedName{Name: ref.Name, Namespace: namespace}
+		switch ref.Kind {
+		case "ConfigMap":
+			cm := &corev1.ConfigMap{}
+			err = f.mcClient.Get(ctx, key, cm)
+			values = cm.Data[valuesKey]
+		case "Secret":
+			secret := &corev1.Secret{}
+			err = f.mcClient.Get(ctx, key, secret)
+			values = string(secret.Data[valuesKey])
+		default:
+			return nil, "", fmt.Errorf("unsupported HelmRelease valuesFrom kind '%s'", ref.Kind)
+		}
+		if errors.IsNotFound(err) && ref.Optional {
+			continue
+		} else if err != nil {
+			return nil, "", fmt.Errorf("failed to get HelmRelease values from %s '%s': %w", ref.Kind, ref.Name, err)
+		}
+
+		layers = append(layers, values)
+	}
+
+	if inlineValues := helmRelease.GetValues(); len(inlineValues) > 0 {
+		data, err := yaml.Marshal(inlineValues)
+		if err != nil {
+			return nil, "", err
+		}
+		layers = append(layers, string(data))
+	}
+
+	values, err := application.MergeValues(layers...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to merge HelmRelease values: %w", err)
+	}
+
+	return helmRelease, values, nil
+}
+
+// GetClusterRelease returns the Release CR from the MC matching the release version (`global.release.version`) set
+// in the cluster's values.
+//
+// If no release version is set in the values, or the cluster's provider is unknown, a nil Release and nil error is
+// returned.
+func (f *Framework) GetClusterRelease(ctx context.Context, cluster *application.Cluster) (*releases.Release, error) {
+	if cluster.ClusterApp == nil {
+		return nil, nil
+	}
+	if cluster.Provider == application.ProviderUnknown {
+		logger.Log("Unable to determine the Release name of cluster '%s' as its provider is unknown", cluster.Name)
+		return nil, nil
+	}
+
+	releaseVersion, err := application.GetReleaseVersionValue(cluster.ClusterApp.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read release version from cluster values: %w", err)
+	}
+	if releaseVersion == "" {
+		logger.Log("No release version found in values of cluster '%s'", cluster.Name)
+		return nil, nil
+	}
+
+	provider := string(cluster.Provider)
+	release := &releases.Release{}
+	releaseName := fmt.Sprintf("%s-%s", provider, strings.TrimPrefix(releaseVersion, fmt.Sprintf("%s-", provider)))
+	if err := f.MC().Get(ctx, cr.ObjectKey{Name: releaseName}, release); err != nil {
+		return nil, fmt.Errorf("failed to get Release '%s' of cluster '%s': %w", releaseName, cluster.Name, err)
+	}
+
+	return release, nil
+}
+
+// checkClusterIsApp returns an error if the chart of the provided cluster is deployed as a Flux HelmRelease instead of
+// an App, as the Framework is only able to delete and upgrade clusters deployed as an App
+func (f *Framework) checkClusterIsApp(ctx context.Context, cluster *application.Cluster) error {
+	key := types.NamespacedName{Name: cluster.Name, Namespace: cluster.GetNamespace()}
+	err := f.MC().Get(ctx, key, &applicationv1alpha1.App{})
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	err = f.MC().Get(ctx, key, &helmv2.HelmRelease{})
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		// Neither exist, e.g. the cluster has already been deleted
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return fmt.Errorf("cluster '%s' is deployed as a HelmRelease which is not supported, only clusters deployed as an App can be deleted or upgraded", cluster.Name)
+}
+
+// releasePairFromRelease returns the ReleasePair that was used to create the provided Release.
+// For Releases created for testing, the original release version and commit are found in annotations, otherwise the
+// version of the Release is used.
+//
+// Test Releases without these annotations were built from the latest release with a random prerelease version that
+// doesn't exist outside of the MC, so an empty ReleasePair is returned to indicate the latest release was used.
+func releasePairFromRelease(release *releases.Release) application.ReleasePair {
+	releasePair := application.ReleasePair{
+		Version: release.Annotations["ci.giantswarm.io/release-version"],
+		Commit:  release.Annotations["ci.giantswarm.io/release-commit"],
+	}
+	if releasePair.Version == "" && !utils.SafeToDelete(release.Annotations) {
+		if version, err := release.GetVersion(); err == nil {
+			releasePair.Version = version
+		}
+	}
+
+	return releasePair
+}
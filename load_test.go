@@ -0,0 +1,332 @@
+package clustertest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
+)
+
+// newLoadTestFramework returns a Framework with a fake MC client containing the provided objects
+func newLoadTestFramework(t *testing.T, objects ...cr.Object) *Framework {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		applicationv1alpha1.AddToScheme,
+		helmv2.AddToScheme,
+		releases.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("Failed to build scheme - %v", err)
+		}
+	}
+
+	mcClient := &client.Client{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()}
+	mcClient.SetKubeconfigProviders(client.KubeconfigProviderFunc(func(_ context.Context, _ *client.Client, _ string, _ string) (string, error) {
+		return teleportKubeconfig, nil
+	}))
+
+	return &Framework{
+		mcClient:  mcClient,
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+}
+
+// newClusterHelmRelease returns a HelmRelease for a cluster chart with the given inline values
+func newClusterHelmRelease(t *testing.T, spec helmv2.HelmReleaseSpec, history helmv2.Snapshots, values map[string]any) *helmv2.HelmRelease {
+	if values != nil {
+		data, err := yaml.Marshal(values)
+		if err != nil {
+			t.Fatalf("Failed to marshal values - %v", err)
+		}
+		json, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			t.Fatalf("Failed to convert values - %v", err)
+		}
+		spec.Values = &apiextensionsv1.JSON{Raw: json}
+	}
+
+	return &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "t-helm", Namespace: "org-test"},
+		Spec:       spec,
+		Status:     helmv2.HelmReleaseStatus{History: history},
+	}
+}
+
+func TestGetClusterApplication(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		objects         []cr.Object
+		clusterName     string
+		expectedAppName string
+		expectedVersion string
+		expectedCatalog string
+		expectedValues  string
+		expectedErr     string
+	}{
+		{
+			name: "app",
+			objects: []cr.Object{
+				&applicationv1alpha1.App{
+					ObjectMeta: metav1.ObjectMeta{Name: "t-app", Namespace: "org-test"},
+					Spec: applicationv1alpha1.AppSpec{
+						Name: "cluster-aws", Version: "2.0.0", Catalog: "cluster",
+						UserConfig: applicationv1alpha1.AppSpecUserConfig{
+							ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: "t-app-userconfig", Namespace: "org-test"},
+						},
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "t-app-userconfig", Namespace: "org-test"},
+					Data:       map[string]string{"values": "global:\n  release:\n    version: 30.0.0\n"},
+				},
+			},
+			clusterName:     "t-app",
+			expectedAppName: "cluster-aws",
+			expectedVersion: "2.0.0",
+			expectedCatalog: "cluster",
+			expectedValues:  "global:\n  release:\n    version: 30.0.0\n",
+		},
+		{
+			name: "helmrelease with chart",
+			objects: []cr.Object{newClusterHelmRelease(t, helmv2.HelmReleaseSpec{
+				Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{
+					Chart: "cluster-azure", Version: "2.1.0", SourceRef: helmv2.CrossNamespaceObjectReference{Kind: "HelmRepository", Name: "cluster-catalog"},
+				}},
+			}, nil, map[string]any{"global": map[string]any{"metadata": map[string]any{"name": "t-helm"}}})},
+			clusterName:     "t-helm",
+			expectedAppName: "cluster-azure",
+			expectedVersion: "2.1.0",
+			expectedCatalog: "cluster-catalog",
+			expectedValues:  "global:\n  metadata:\n    name: t-helm\n",
+		},
+		{
+			name: "helmrelease with semver range",
+			objects: []cr.Object{newClusterHelmRelease(t, helmv2.HelmReleaseSpec{
+				Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{
+					Chart: "cluster-azure", Version: ">=2.0.0", SourceRef: helmv2.CrossNamespaceObjectReference{Kind: "HelmRepository", Name: "cluster-catalog"},
+				}},
+			}, helmv2.Snapshots{
+				{Version: 1, ChartName: "cluster-azure", ChartVersion: "2.0.0"},
+				{Version: 2, ChartName: "cluster-azure", ChartVersion: "2.3.1"},
+			}, nil)},
+			clusterName:     "t-helm",
+			expectedAppName: "cluster-azure",
+			expectedVersion: "2.3.1",
+			expectedCatalog: "cluster-catalog",
+			expectedValues:  "{}\n",
+		},
+		{
+			name: "helmrelease with chartRef",
+			objects: []cr.Object{newClusterHelmRelease(t, helmv2.HelmReleaseSpec{
+				ChartRef: &helmv2.CrossNamespaceSourceReference{Kind: "OCIRepository", Name: "cluster-aws-chart"},
+			}, helmv2.Snapshots{
+				{Version: 1, ChartName: "cluster-aws", ChartVersion: "3.2.0"},
+			}, nil)},
+			clusterName:     "t-helm",
+			expectedAppName: "cluster-aws",
+			expectedVersion: "3.2.0",
+			expectedCatalog: "cluster-aws-chart",
+			expectedValues:  "{}\n",
+		},
+		{
+			name:        "not found",
+			clusterName: "t-missing",
+			expectedErr: "no cluster App or HelmRelease found for cluster 'org-test/t-missing'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newLoadTestFramework(t, tc.objects...)
+
+			clusterApp, err := f.getClusterApplication(ctx, tc.clusterName, "org-test")
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("Error not as expected. Expected: %s, Actual: %v", tc.expectedErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+
+			if clusterApp.InstallName != tc.clusterName || clusterApp.AppName != tc.expectedAppName || clusterApp.RepoName != tc.expectedAppName {
+				t.Errorf("Names not as expected. Expected: %s/%s, Actual: %s/%s", tc.clusterName, tc.expectedAppName, clusterApp.InstallName, clusterApp.AppName)
+			}
+			if clusterApp.Version != tc.expectedVersion {
+				t.Errorf("Version not as expected. Expected: %s, Actual: %s", tc.expectedVersion, clusterApp.Version)
+			}
+			if clusterApp.Catalog != tc.expectedCatalog {
+				t.Errorf("Catalog not as expected. Expected: %s, Actual: %s", tc.expectedCatalog, clusterApp.Catalog)
+			}
+			if clusterApp.Values != tc.expectedValues {
+				t.Errorf("Values not as expected.\nExpected:\n%s\nActual:\n%s", tc.expectedValues, clusterApp.Values)
+			}
+		})
+	}
+}
+
+func TestGetHelmReleaseAndValues(t *testing.T) {
+	ctx := context.Background()
+
+	helmRelease := newClusterHelmRelease(t, helmv2.HelmReleaseSpec{
+		ValuesFrom: []helmv2.ValuesReference{
+			{Kind: "ConfigMap", Name: "base"},
+			{Kind: "Secret", Name: "secret-values", ValuesKey: "overrides"},
+			{Kind: "ConfigMap", Name: "missing", Optional: true},
+			{Kind: "ConfigMap", Name: "nested", TargetPath: "global.metadata.name"},
+		},
+	}, nil, map[string]any{"inline": "inline"})
+	f := newLoadTestFramework(t,
+		helmRelease,
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "org-test"},
+			Data:       map[string]string{"values.yaml": "base: base\nsecret: base\ninline: base\n"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-values", Namespace: "org-test"},
+			Data:       map[string][]byte{"overrides": []byte("secret: secret\ninline: secret\n")},
+		},
+	)
+
+	_, values, err := f.GetHelmReleaseAndValues(ctx, "t-helm", "org-test")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	// Each layer overrides the previous ones with the inline values last
+	expected := "base: base\ninline: inline\nsecret: secret\n"
+	if values != expected {
+		t.Errorf("Values not as expected.\nExpected:\n%s\nActual:\n%s", expected, values)
+	}
+
+	helmRelease.Spec.ValuesFrom = append(helmRelease.Spec.ValuesFrom, helmv2.ValuesReference{Kind: "ConfigMap", Name: "missing"})
+	if err := f.MC().Update(ctx, helmRelease); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if _, _, err := f.GetHelmReleaseAndValues(ctx, "t-helm", "org-test"); err == nil {
+		t.Errorf("Expected an error to be returned for a missing required valuesFrom reference")
+	}
+}
+
+func TestLoadClusterByName(t *testing.T) {
+	t.Setenv(env.KeepWorkloadCluster, "")
+	ctx := context.Background()
+
+	clusterHelmRelease := func(chart string) *helmv2.HelmRelease {
+		return newClusterHelmRelease(t, helmv2.HelmReleaseSpec{
+			Chart: &helmv2.HelmChartTemplate{Spec: helmv2.HelmChartTemplateSpec{Chart: chart, Version: "3.0.0"}},
+		}, nil, map[string]any{"global": map[string]any{"release": map[string]any{"version": "30.0.0"}}})
+	}
+
+	tests := []struct {
+		name                string
+		objects             []cr.Object
+		expectedRelease     application.ReleasePair
+		expectedReleaseName string
+	}{
+		{
+			name:                "release found",
+			objects:             []cr.Object{clusterHelmRelease("cluster-aws"), &releases.Release{ObjectMeta: metav1.ObjectMeta{Name: "aws-30.0.0"}}},
+			expectedRelease:     application.ReleasePair{Version: "30.0.0"},
+			expectedReleaseName: "aws-30.0.0",
+		},
+		{
+			name:    "release not found",
+			objects: []cr.Object{clusterHelmRelease("cluster-aws")},
+		},
+		{
+			name:    "unknown provider",
+			objects: []cr.Object{clusterHelmRelease("cluster-example"), &releases.Release{ObjectMeta: metav1.ObjectMeta{Name: "-30.0.0"}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newLoadTestFramework(t, tc.objects...)
+
+			cluster, err := f.LoadClusterByName(ctx, "t-helm", "org-test")
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if cluster.Release != tc.expectedRelease {
+				t.Errorf("Release not as expected. Expected: %+v, Actual: %+v", tc.expectedRelease, cluster.Release)
+			}
+			if clusters := f.GetState().Clusters; len(clusters) != 1 || clusters[0].ReleaseName != tc.expectedReleaseName {
+				t.Errorf("Cluster state not as expected. Actual: %+v", clusters)
+			}
+			if _, err := f.WC("t-helm"); err != nil {
+				t.Errorf("Expected a client to be set up for the cluster - %v", err)
+			}
+
+			// Only clusters deployed as an App can be deleted
+			err = f.DeleteCluster(ctx, cluster)
+			if err == nil || !strings.Contains(err.Error(), "deployed as a HelmRelease") {
+				t.Errorf("Expected an error to be returned when deleting a HelmRelease cluster. Actual: %v", err)
+			}
+			if _, _, err := f.GetHelmReleaseAndValues(ctx, "t-helm", "org-test"); err != nil {
+				t.Errorf("Expected the HelmRelease to not be deleted - %v", err)
+			}
+			if _, err := f.UpgradeCluster(ctx, cluster, application.ReleasePair{Version: "31.0.0"}); err == nil || !strings.Contains(err.Error(), "deployed as a HelmRelease") {
+				t.Errorf("Expected an error to be returned when upgrading a HelmRelease cluster. Actual: %v", err)
+			}
+		})
+	}
+}
+
+func TestReleasePairFromRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		release  metav1.ObjectMeta
+		expected application.ReleasePair
+	}{
+		{
+			name:     "existing release",
+			release:  metav1.ObjectMeta{Name: "aws-30.1.0"},
+			expected: application.ReleasePair{Version: "30.1.0"},
+		},
+		{
+			name: "test release for a releases PR",
+			release: metav1.ObjectMeta{Name: "aws-30.1.0-abc12", Annotations: map[string]string{
+				utils.DeleteAnnotation:             "true",
+				"ci.giantswarm.io/release-version": "30.1.0",
+				"ci.giantswarm.io/release-commit":  "abcdef",
+			}},
+			expected: application.ReleasePair{Version: "30.1.0", Commit: "abcdef"},
+		},
+		{
+			name:     "test release based on the latest release",
+			release:  metav1.ObjectMeta{Name: "aws-30.1.0-abc12", Annotations: map[string]string{utils.DeleteAnnotation: "true"}},
+			expected: application.ReleasePair{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := releasePairFromRelease(&releases.Release{ObjectMeta: tc.release})
+			if actual != tc.expected {
+				t.Errorf("ReleasePair not as expected. Expected: %+v, Actual: %+v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package clustertest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
+)
+
+// Options are the options available when creating a new Framework with `NewWithOptions`.
+//
+// Any option left unset falls back to its matching env var from the `env` package, if set.
+type Options struct {
+	// KubeconfigPath is the path to the kubeconfig file of the Management Cluster.
+	// Defaults to the `E2E_KUBECONFIG` env var.
+	KubeconfigPath string
+	// Kubeconfig is the raw contents of the kubeconfig of the Management Cluster.
+	// If set, this takes precedence over `KubeconfigPath`.
+	Kubeconfig []byte
+	// Context is the context in the kubeconfig to use. Defaults to the current context of the kubeconfig.
+	Context string
+
+	// KeepWorkloadCluster indicates if the deletion of workload clusters and test resources should be skipped.
+	// Defaults to the `E2E_WC_KEEP` env var.
+	KeepWorkloadCluster *bool
+
+	// OverrideVersions are app versions to use in place of the latest, keyed by app name with values in the format
+	// `version[:catalog]`. Defaults to the `E2E_OVERRIDE_VERSIONS` env var.
+	OverrideVersions map[string]string
+	// ReleaseVersion is the Release to use for clusters that don't specify one.
+	// Defaults to the `E2E_RELEASE_VERSION` env var.
+	ReleaseVersion string
+	// ReleaseCommit is the commit of `giantswarm/releases` to fetch the Release from for clusters that don't specify one.
+	// It is only used alongside `ReleaseVersion`, not for clusters that specify their own release version.
+	// Defaults to the `E2E_RELEASE_COMMIT` env var.
+	ReleaseCommit string
+
+	// ClientQPS is the client-go QPS used by the Framework's clients. Defaults to the `E2E_CLIENT_QPS` env var.
+	ClientQPS float32
+	// ClientBurst is the client-go Burst used by the Framework's clients. Defaults to the `E2E_CLIENT_BURST` env var.
+	ClientBurst int
+
+	// KubeconfigProviders are used, in order, to get the kubeconfig of workload clusters.
+	// Defaults to `client.DefaultKubeconfigProviders()`.
+	KubeconfigProviders []client.KubeconfigProvider
+}
+
+// NewWithOptions initializes a new Framework instance configured with the provided Options instead of relying only on
+// env vars. This allows for multiple, differently configured, Frameworks to be used within the same process.
+//
+// The `OverrideVersions`, `ReleaseVersion` and `ReleaseCommit` options are applied to clusters passed to
+// `ApplyCluster`, `ApplyClusters` and `UpgradeCluster` where the cluster doesn't already specify its own release or
+// cluster app version. `OverrideVersions` are also used for apps deployed with `DeployApp` through the Framework's MC
+// and WC clients.
+//
+// Example:
+//
+//	keep := true
+//	framework, err := clustertest.NewWithOptions(clustertest.Options{
+//		KubeconfigPath:      "/path/to/kubeconfig",
+//		Context:             "teleport.giantswarm.io-golem",
+//		KeepWorkloadCluster: &keep,
+//		OverrideVersions:    map[string]string{"cluster-aws": "v1.2.3"},
+//	})
+func NewWithOptions(opts Options) (*Framework, error) {
+	clientOpts := client.Options{
+		Context:          opts.Context,
+		QPS:              opts.ClientQPS,
+		Burst:            opts.ClientBurst,
+		VersionOverrides: opts.OverrideVersions,
+	}
+
+	var mcClient *client.Client
+	var err error
+	if len(opts.Kubeconfig) > 0 {
+		mcClient, err = client.NewFromRawKubeconfigWithOptions(opts.Kubeconfig, clientOpts)
+	} else {
+		if opts.KubeconfigPath == "" {
+			kubeconfigPath, ok := os.LookupEnv(env.Kubeconfig)
+			if !ok {
+				return nil, fmt.Errorf("no kubeconfig provided and no %s set", env.Kubeconfig)
+			}
+			opts.KubeconfigPath = kubeconfigPath
+		}
+		mcClient, err = client.NewWithOptions(opts.KubeconfigPath, clientOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.KubeconfigProviders) > 0 {
+		mcClient.SetKubeconfigProviders(opts.KubeconfigProviders...)
+	}
+
+	return &Framework{
+		mcKubeconfigPath: opts.KubeconfigPath,
+		mcClient:         mcClient,
+		wcClients:        map[string]*client.Client{},
+		timings:          timing.NewRecorder(),
+		options:          opts,
+	}, nil
+}
+
+// shouldKeepWorkloadCluster returns true if test resources should be kept, based on the `KeepWorkloadCluster` option
+// or, if not set, the `E2E_WC_KEEP` env var
+func (f *Framework) shouldKeepWorkloadCluster() bool {
+	if f.options.KeepWorkloadCluster != nil {
+		return *f.options.KeepWorkloadCluster
+	}
+
+	keep := strings.ToLower(os.Getenv(env.KeepWorkloadCluster))
+	return keep != "" && keep != "false"
+}
+
+// clientOptions returns the options to use when creating new clients for the Framework
+func (f *Framework) clientOptions() client.Options {
+	return client.Options{
+		QPS:              f.options.ClientQPS,
+		Burst:            f.options.ClientBurst,
+		VersionOverrides: f.options.OverrideVersions,
+	}
+}
+
+// applyClusterDefaults sets the Framework's configured release and version overrides on the Cluster where it doesn't
+// already specify its own. The release commit is only set along with the release version so a cluster's own release
+// version is never combined with the Framework's commit.
+func (f *Framework) applyClusterDefaults(cluster *application.Cluster) {
+	if cluster.Release.Version == "" {
+		cluster.Release.Version = f.options.ReleaseVersion
+		if cluster.Release.Commit == "" {
+			cluster.Release.Commit = f.options.ReleaseCommit
+		}
+	}
+	if len(f.options.OverrideVersions) > 0 {
+		cluster.WithVersionOverrides(f.options.OverrideVersions)
+	}
+}
@@ -0,0 +1,32 @@
+package clustertest
+
+import (
+	"testing"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+)
+
+func TestApplyClusterDefaults_Release(t *testing.T) {
+	f := &Framework{options: Options{ReleaseVersion: "30.0.0", ReleaseCommit: "abc123"}}
+
+	tests := []struct {
+		name     string
+		release  application.ReleasePair
+		expected application.ReleasePair
+	}{
+		{name: "defaults", release: application.ReleasePair{}, expected: application.ReleasePair{Version: "30.0.0", Commit: "abc123"}},
+		{name: "own version", release: application.ReleasePair{Version: "29.1.0"}, expected: application.ReleasePair{Version: "29.1.0"}},
+		{name: "own version and commit", release: application.ReleasePair{Version: "29.1.0", Commit: "def456"}, expected: application.ReleasePair{Version: "29.1.0", Commit: "def456"}},
+		{name: "own commit", release: application.ReleasePair{Commit: "def456"}, expected: application.ReleasePair{Version: "30.0.0", Commit: "def456"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &application.Cluster{Name: "t-defaults", Release: tc.release}
+			f.applyClusterDefaults(cluster)
+			if cluster.Release != tc.expected {
+				t.Errorf("Release not as expected. Expected: %+v, Actual: %+v", tc.expected, cluster.Release)
+			}
+		})
+	}
+}
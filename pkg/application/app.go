@@ -46,6 +46,8 @@ type Application struct {
 
 	AppLabels       map[string]string
 	ConfigMapLabels map[string]string
+
+	versionOverrides map[string]overrideVersion
 }
 
 // New creates a new Application
@@ -207,16 +209,25 @@ func (a *Application) WithInstallNamespace(namespace string) *Application {
 	return a
 }
 
+// WithVersionOverrides sets app versions to use in place of those found in the `E2E_OVERRIDE_VERSIONS` env var when the
+// Version is left blank. The provided map is keyed by app name with values in the format `version[:catalog]`,
+// e.g. `cilium: v1.2.3`.
+func (a *Application) WithVersionOverrides(overrides map[string]string) *Application {
+	a.versionOverrides = mergeOverrideVersions(a.versionOverrides, overrides)
+	return a
+}
+
 // Build generates the App and ConfigMap resources
 func (a *Application) Build() (*applicationv1alpha1.App, *corev1.ConfigMap, error) {
 	switch a.Version {
 	case "":
-		// When the version is left blank we'll look for an override version from the env vars.
+		// When the version is left blank we'll look for an override version, first from those provided with
+		// `WithVersionOverrides` and then from the env vars.
 		// The env var `E2E_OVERRIDE_VERSIONS` is used to provide a comma seperated list
 		// of app version overrides in the format of `app-name=version[:catalog]`.
 		// E.g. for `cluster-aws` the env var might contain `cluster-aws=v1.2.3` or `cluster-aws=v1.2.3:cluster-test`
-		// If no matching env var is found we'll fallback to fetching the latest version
-		ver, catalog, ok := getOverrideVersion(a.AppName)
+		// If no matching override is found we'll fallback to fetching the latest version
+		ver, catalog, ok := a.getOverrideVersion()
 		if ok {
 			if catalog != "" {
 				a = a.WithCatalog(catalog)
@@ -293,6 +304,15 @@ func (a *Application) Build() (*applicationv1alpha1.App, *corev1.ConfigMap, erro
 	return app, configmap, nil
 }
 
+// getOverrideVersion returns the override version and catalog for the app, preferring those provided with
+// `WithVersionOverrides` over the env vars
+func (a *Application) getOverrideVersion() (string, string, bool) {
+	if ov, ok := a.versionOverrides[strings.ToLower(a.AppName)]; ok {
+		return ov.Version, ov.Catalog, true
+	}
+	return getOverrideVersion(a.AppName)
+}
+
 // GetNamespace returns the namespace the App CR will be applied in.
 func (a *Application) GetNamespace() string {
 	if a.InCluster {
@@ -554,3 +554,31 @@ func TestWithVersion_Catalog(t *testing.T) {
 		t.Errorf("Was expecting catalog to be the provided with the test suffix. Expected: %s, Actual: %s", "override", app.Spec.Catalog)
 	}
 }
+
+func TestApplicationWithVersionOverrides(t *testing.T) {
+	t.Setenv(env.OverrideVersions, "cilium=v9.9.9,coredns=v1.0.0")
+
+	app, _, err := New("cilium", "cilium").
+		WithVersionOverrides(map[string]string{"cilium": "v1.2.3:cluster-test"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if app.Spec.Version != "v1.2.3" {
+		t.Errorf("Version not as expected. Expected: v1.2.3, Actual: %s", app.Spec.Version)
+	}
+	if app.Spec.Catalog != "cluster-test" {
+		t.Errorf("Catalog not as expected. Expected: cluster-test, Actual: %s", app.Spec.Catalog)
+	}
+
+	// Apps without a provided override still fall back to the env var
+	app, _, err = New("coredns", "coredns").
+		WithVersionOverrides(map[string]string{"cilium": "v1.2.3"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if app.Spec.Version != "v1.0.0" {
+		t.Errorf("Version not as expected. Expected: v1.0.0, Actual: %s", app.Spec.Version)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	releasesapi "github.com/giantswarm/releases/sdk"
 	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 
 	"github.com/giantswarm/clustertest/v5/pkg/env"
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
@@ -25,7 +26,8 @@ type Cluster struct {
 	Organization *organization.Org
 	Release      ReleasePair
 
-	appOverrides []Application
+	appOverrides     []Application
+	versionOverrides map[string]overrideVersion
 }
 
 // AppPair wraps an App and it's ConfigMap into a single struct
@@ -127,6 +129,29 @@ func (c *Cluster) WithExtraConfigs(extraConfigs []applicationv1alpha1.AppExtraCo
 	return c
 }
 
+// WithVersionOverrides sets app versions to use in place of those found in the `E2E_OVERRIDE_VERSIONS` env var.
+// The provided map is keyed by app name with values in the format `version[:catalog]`, e.g. `cluster-aws: v1.2.3`.
+//
+// The overrides are only used for the cluster app, and only if its version hasn't been explicitly set.
+func (c *Cluster) WithVersionOverrides(overrides map[string]string) *Cluster {
+	c.versionOverrides = mergeOverrideVersions(c.versionOverrides, overrides)
+	return c
+}
+
+// applyVersionOverrides sets the cluster app version from the version overrides if one hasn't been explicitly set
+func (c *Cluster) applyVersionOverrides() {
+	if c.ClusterApp.Version != "" {
+		return
+	}
+
+	if ov, ok := c.versionOverrides[strings.ToLower(c.ClusterApp.AppName)]; ok {
+		if ov.Catalog != "" {
+			c.ClusterApp = c.ClusterApp.WithCatalog(ov.Catalog)
+		}
+		c.ClusterApp = c.ClusterApp.WithVersion(ov.Version)
+	}
+}
+
 // WithRelease sets the release version and commit to use for this Cluster
 func (c *Cluster) WithRelease(releasePair ReleasePair) *Cluster {
 	c.Release = releasePair
@@ -142,7 +167,16 @@ func (c *Cluster) WithRelease(releasePair ReleasePair) *Cluster {
 // When the latest published Release is used, the cluster app version is only overridden if it was explicitly requested
 // (via a non-empty cluster app version or an `E2E_OVERRIDE_VERSIONS` entry for the cluster app). Otherwise the Release's
 // pinned cluster app version is left untouched so the cluster chart is not incidentally bumped to its latest version.
+//
+// The Release manifests are always fetched from GitHub using the releases SDK so an error is returned if an
+// `utils.OfflineVersionResolver` is in use.
 func (c *Cluster) GetRelease() (*releases.Release, error) {
+	if utils.IsOffline() {
+		return nil, fmt.Errorf("unable to build Release for cluster '%s' as the Release manifests are fetched from GitHub and an offline version resolver is in use", c.Name)
+	}
+
+	c.applyVersionOverrides()
+
 	provider := releases.Provider(c.Provider)
 
 	releaseClient := releasesapi.NewClientWithGitHubToken(utils.GetGitHubToken())
@@ -277,6 +311,9 @@ func (c *Cluster) WithAppOverride(app Application) *Cluster {
 
 // Build defaults and populates some required values on the apps then generates the App and ConfigMap pairs for the
 // cluster app as well as the Release CR.
+//
+// Like the Organization and Release, the cluster App is annotated with `utils.DeleteAnnotation` to mark it as created
+// by E2E tests, allowing the janitor to remove it once orphaned.
 func (c *Cluster) Build() (*BuiltCluster, error) {
 	builtCluster := &BuiltCluster{
 		SourceCluster: c,
@@ -284,6 +321,8 @@ func (c *Cluster) Build() (*BuiltCluster, error) {
 
 	baseLabels := utils.GetBaseLabels()
 
+	c.applyVersionOverrides()
+
 	// Build Cluster App
 	c.ClusterApp.
 		WithAppLabels(mergeMaps(baseLabels, map[string]string{
@@ -305,6 +344,10 @@ func (c *Cluster) Build() (*BuiltCluster, error) {
 	if err != nil {
 		return builtCluster, err
 	}
+	// Mark the cluster App as being safe to delete so orphaned test clusters can be found and cleaned up
+	clusterApplication.Annotations = mergeMaps(clusterApplication.GetAnnotations(), map[string]string{
+		utils.DeleteAnnotation: "true",
+	})
 	builtCluster.Cluster = &AppPair{App: clusterApplication, ConfigMap: clusterCM}
 
 	// Build Release
@@ -322,14 +365,42 @@ func (c *Cluster) Build() (*BuiltCluster, error) {
 		return builtCluster, err
 	}
 
+	builtCluster.Cluster.ConfigMap.Data["values"], err = SetReleaseVersionValue(builtCluster.Cluster.ConfigMap.Data["values"], releaseVersion)
+	if err != nil {
+		return builtCluster, err
+	}
+
+	return builtCluster, nil
+}
+
+// SetReleaseVersionValue returns the provided cluster values with `global.release.version` set to the given release
+// version, keeping all other values untouched.
+func SetReleaseVersionValue(values string, releaseVersion string) (string, error) {
 	releaseValues := fmt.Sprintf(`global:
     release:
       version: "%s"`, releaseVersion)
 
-	builtCluster.Cluster.ConfigMap.Data["values"], err = mergeValues(builtCluster.Cluster.ConfigMap.Data["values"], releaseValues)
-	if err != nil {
-		return builtCluster, err
+	return mergeValues(values, releaseValues)
+}
+
+// GetReleaseVersionValue returns the `global.release.version` set in the provided cluster values, or an empty string if
+// not set.
+func GetReleaseVersionValue(values string) (string, error) {
+	parsed := struct {
+		Global struct {
+			Release struct {
+				Version string `json:"version"`
+			} `json:"release"`
+		} `json:"global"`
+	}{}
+	if err := yaml.Unmarshal([]byte(values), &parsed); err != nil {
+		return "", err
 	}
 
-	return builtCluster, nil
+	return parsed.Global.Release.Version, nil
+}
+
+// MergeValues merges the provided YAML values layers into one, with values from later layers taking precedence
+func MergeValues(layers ...string) (string, error) {
+	return mergeValues(layers...)
 }
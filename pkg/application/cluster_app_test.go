@@ -1,7 +1,12 @@
 package application
 
 import (
+	"path"
+	"strings"
 	"testing"
+
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
 )
 
 func TestClusterAppDefaults(t *testing.T) {
@@ -16,3 +21,87 @@ func TestClusterAppDefaults(t *testing.T) {
 		t.Errorf("ClusterApp namespace not as expected. Expected %s, Actual: %s", cluster.GetNamespace(), cluster.ClusterApp.Organization.GetNamespace())
 	}
 }
+
+func TestSetReleaseVersionValue(t *testing.T) {
+	values := `global:
+  metadata:
+    name: example
+  release:
+    version: "25.0.0"
+`
+
+	actual, err := SetReleaseVersionValue(values, "26.1.0")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if !strings.Contains(actual, `version: 26.1.0`) {
+		t.Errorf("Release version not updated. Actual: %s", actual)
+	}
+	if !strings.Contains(actual, `name: example`) {
+		t.Errorf("Existing values not kept. Actual: %s", actual)
+	}
+}
+
+func TestGetReleaseVersionValue(t *testing.T) {
+	values := `global:
+  metadata:
+    name: example
+  release:
+    version: "25.0.0"
+`
+
+	actual, err := GetReleaseVersionValue(values)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if actual != "25.0.0" {
+		t.Errorf("Release version not as expected. Expected: 25.0.0, Actual: %s", actual)
+	}
+
+	actual, err = GetReleaseVersionValue(`global: {}`)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if actual != "" {
+		t.Errorf("Release version not as expected. Expected empty string, Actual: %s", actual)
+	}
+}
+
+func TestWithVersionOverrides(t *testing.T) {
+	t.Setenv(env.OverrideVersions, "cluster-aws=v9.9.9")
+
+	cluster := NewClusterApp("example", ProviderAWS).
+		WithVersionOverrides(map[string]string{"cluster-aws": "v1.2.3:cluster-test"})
+	cluster.applyVersionOverrides()
+
+	if cluster.ClusterApp.Version != "v1.2.3" {
+		t.Errorf("ClusterApp version not as expected. Expected: v1.2.3, Actual: %s", cluster.ClusterApp.Version)
+	}
+	if cluster.ClusterApp.Catalog != "cluster-test" {
+		t.Errorf("ClusterApp catalog not as expected. Expected: cluster-test, Actual: %s", cluster.ClusterApp.Catalog)
+	}
+
+	// An explicitly set version takes precedence over any override
+	cluster = NewClusterApp("example", ProviderAWS).
+		WithAppVersions("v2.0.0").
+		WithVersionOverrides(map[string]string{"cluster-aws": "v1.2.3"})
+	cluster.applyVersionOverrides()
+
+	if cluster.ClusterApp.Version != "v2.0.0" {
+		t.Errorf("ClusterApp version not as expected. Expected: v2.0.0, Actual: %s", cluster.ClusterApp.Version)
+	}
+}
+
+func TestGetRelease_Offline(t *testing.T) {
+	utils.SetVersionResolver(utils.NewOfflineVersionResolver(path.Join("..", "utils", "test_data", "versions.yaml")))
+	defer utils.SetVersionResolver(nil)
+
+	cluster := NewClusterApp("example", ProviderAWS).WithAppVersions("v1.0.0")
+	if _, err := cluster.GetRelease(); err == nil || !strings.Contains(err.Error(), "offline") {
+		t.Errorf("Expected an error to be returned when offline. Actual: %v", err)
+	}
+	if _, err := cluster.Build(); err == nil {
+		t.Errorf("Expected an error to be returned when building a cluster offline")
+	}
+}
@@ -24,7 +24,12 @@ const (
 
 // ProviderFromClusterApplication returns the appropriate Provider related to the given cluster app
 func ProviderFromClusterApplication(app *applicationv1alpha1.App) Provider {
-	switch strings.ToLower(app.Spec.Name) {
+	return ProviderFromClusterAppName(app.Spec.Name)
+}
+
+// ProviderFromClusterAppName returns the appropriate Provider related to the given cluster app (or chart) name
+func ProviderFromClusterAppName(appName string) Provider {
+	switch strings.ToLower(appName) {
 	case "cluster-aws":
 		return ProviderAWS
 	case "cluster-eks":
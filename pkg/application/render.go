@@ -0,0 +1,145 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Objects returns all the resources that make up the BuiltCluster in the order they are applied to the MC:
+// the Release CR (if set), the Organization CR, the cluster App and its values ConfigMap.
+func (b *BuiltCluster) Objects() ([]cr.Object, error) {
+	objects := []cr.Object{}
+
+	if b.Release != nil {
+		release := b.Release.DeepCopy()
+		release.APIVersion = releases.SchemeGroupVersion.String()
+		release.Kind = "Release"
+		objects = append(objects, release)
+	}
+
+	if b.SourceCluster != nil && b.SourceCluster.Organization != nil {
+		orgCR, err := b.SourceCluster.Organization.Build()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build organization: %w", err)
+		}
+		objects = append(objects, orgCR)
+	}
+
+	if b.Cluster != nil {
+		if b.Cluster.App != nil {
+			app := b.Cluster.App.DeepCopy()
+			app.APIVersion = applicationv1alpha1.SchemeGroupVersion.String()
+			app.Kind = "App"
+			objects = append(objects, app)
+		}
+		if b.Cluster.ConfigMap != nil {
+			cm := b.Cluster.ConfigMap.DeepCopy()
+			cm.APIVersion = corev1.SchemeGroupVersion.String()
+			cm.Kind = "ConfigMap"
+			objects = append(objects, cm)
+		}
+	}
+
+	return objects, nil
+}
+
+// WriteYAML writes all the resources of the BuiltCluster to the provided writer as a multi-document YAML stream,
+// in the order they would be applied to the MC.
+//
+// Example:
+//
+//	builtCluster, _ := cluster.Build()
+//	err := builtCluster.WriteYAML(os.Stdout)
+func (b *BuiltCluster) WriteYAML(w io.Writer) error {
+	objects, err := b.Objects()
+	if err != nil {
+		return err
+	}
+
+	for i, obj := range objects {
+		data, err := marshalObject(obj)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteToDir writes each of the resources of the BuiltCluster to its own YAML file within the provided directory,
+// creating the directory if needed. Files are prefixed with a number indicating the order they would be applied in.
+//
+// Example:
+//
+//	builtCluster, _ := cluster.Build()
+//	err := builtCluster.WriteToDir("./manifests")
+func (b *BuiltCluster) WriteToDir(dir string) error {
+	objects, err := b.Objects()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory '%s': %w", dir, err)
+	}
+
+	for i, obj := range objects {
+		data, err := marshalObject(obj)
+		if err != nil {
+			return err
+		}
+
+		fileName := fmt.Sprintf("%02d-%s-%s.yaml", i, strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), obj.GetName())
+		if err := os.WriteFile(filepath.Join(dir, fileName), data, 0o600); err != nil {
+			return fmt.Errorf("failed to write '%s': %w", fileName, err)
+		}
+	}
+
+	return nil
+}
+
+// RenderYAML returns all the resources of the BuiltCluster as a multi-document YAML string.
+// This is useful for golden-file testing of cluster values.
+func (b *BuiltCluster) RenderYAML() (string, error) {
+	buf := &bytes.Buffer{}
+	err := b.WriteYAML(buf)
+	return buf.String(), err
+}
+
+// marshalObject converts the object to YAML, dropping the status and any server populated metadata as these are never
+// applied to the cluster
+func marshalObject(obj cr.Object) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s '%s': %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+	delete(content, "status")
+	unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s '%s': %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+	}
+	return data, nil
+}
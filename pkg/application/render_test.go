@@ -0,0 +1,114 @@
+package application
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func newTestBuiltCluster(t *testing.T) *BuiltCluster {
+	t.Helper()
+
+	cluster := NewClusterApp("golden", ProviderAWS).
+		WithOrg(organization.New("golden-org"))
+	cluster.ClusterApp = cluster.ClusterApp.
+		WithVersion("1.2.3").
+		MustWithValues("global:\n  metadata:\n    description: golden test\n", nil)
+
+	app, cm, err := cluster.ClusterApp.Build()
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	return &BuiltCluster{
+		SourceCluster: cluster,
+		Cluster:       &AppPair{App: app, ConfigMap: cm},
+		Release: &releases.Release{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-30.0.0"},
+		},
+	}
+}
+
+func TestRenderYAML(t *testing.T) {
+	// Unset the CI env vars used for the base labels so the output matches wherever the test is run
+	for _, envVar := range []string{"TEKTON_PIPELINE_RUN", "TEKTON_TASK_RUN", "CICD_PR_NUMBER", "CICD_TRIGGER_USER", "CICD_REPO"} {
+		t.Setenv(envVar, "")
+	}
+
+	builtCluster := newTestBuiltCluster(t)
+
+	actual, err := builtCluster.RenderYAML()
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	goldenFile := path.Join("test_data", "rendered_cluster.golden.yaml")
+	if *update {
+		if err := os.WriteFile(goldenFile, []byte(actual), 0o600); err != nil {
+			t.Fatalf("Failed to update golden file - %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("Failed to read golden file - %v", err)
+	}
+
+	if actual != string(expected) {
+		t.Errorf("Rendered cluster not as expected (run with -update to regenerate). Expected:\n%s\nActual:\n%s", expected, actual)
+	}
+}
+
+func TestWriteToDir(t *testing.T) {
+	builtCluster := newTestBuiltCluster(t)
+	dir := t.TempDir()
+
+	if err := builtCluster.WriteToDir(dir); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	expectedFiles := []string{
+		"00-release-aws-30.0.0.yaml",
+		"01-organization-golden-org.yaml",
+		"02-app-golden.yaml",
+		"03-configmap-golden-userconfig.yaml",
+	}
+	actualFiles := []string{}
+	for _, entry := range entries {
+		actualFiles = append(actualFiles, entry.Name())
+	}
+	if strings.Join(actualFiles, ",") != strings.Join(expectedFiles, ",") {
+		t.Errorf("Files not as expected. Expected: %v, Actual: %v", expectedFiles, actualFiles)
+	}
+
+	// Each file should match the corresponding document of the YAML stream
+	stream := &bytes.Buffer{}
+	if err := builtCluster.WriteYAML(stream); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	documents := strings.Split(stream.String(), "---\n")
+	for i, fileName := range expectedFiles {
+		data, err := os.ReadFile(path.Join(dir, fileName))
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		if string(data) != documents[i] {
+			t.Errorf("File '%s' doesn't match the YAML stream. Expected:\n%s\nActual:\n%s", fileName, documents[i], data)
+		}
+	}
+}
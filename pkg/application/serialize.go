@@ -0,0 +1,49 @@
+package application
+
+import (
+	"encoding/json"
+
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+// clusterJSON is the serialized form of a Cluster, including the app and version overrides not otherwise exported
+type clusterJSON struct {
+	Name             string                     `json:"name"`
+	Provider         Provider                   `json:"provider"`
+	ClusterApp       *Application               `json:"clusterApp,omitempty"`
+	Organization     *organization.Org          `json:"organization,omitempty"`
+	Release          ReleasePair                `json:"release"`
+	AppOverrides     []Application              `json:"appOverrides,omitempty"`
+	VersionOverrides map[string]overrideVersion `json:"versionOverrides,omitempty"`
+}
+
+// MarshalJSON serializes the Cluster, including any app overrides and version overrides, so that it can be
+// restored later (e.g. by a different process) with UnmarshalJSON
+func (c *Cluster) MarshalJSON() ([]byte, error) {
+	return json.Marshal(clusterJSON{
+		Name:             c.Name,
+		Provider:         c.Provider,
+		ClusterApp:       c.ClusterApp,
+		Organization:     c.Organization,
+		Release:          c.Release,
+		AppOverrides:     c.appOverrides,
+		VersionOverrides: c.versionOverrides,
+	})
+}
+
+// UnmarshalJSON restores a Cluster previously serialized with MarshalJSON
+func (c *Cluster) UnmarshalJSON(data []byte) error {
+	serialized := clusterJSON{}
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return err
+	}
+
+	c.Name = serialized.Name
+	c.Provider = serialized.Provider
+	c.ClusterApp = serialized.ClusterApp
+	c.Organization = serialized.Organization
+	c.Release = serialized.Release
+	c.appOverrides = serialized.AppOverrides
+	c.versionOverrides = serialized.VersionOverrides
+	return nil
+}
@@ -0,0 +1,51 @@
+package application
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+func TestClusterJSONRoundTrip(t *testing.T) {
+	cluster := NewClusterApp("example", ProviderAWS).
+		WithOrg(organization.New("test-org")).
+		WithRelease(ReleasePair{Version: "30.0.0", Commit: "abc123"}).
+		WithVersionOverrides(map[string]string{"cluster-aws": "v1.2.3:cluster-test"})
+	cluster.appOverrides = append(cluster.appOverrides, *New("example-cilium", "cilium").WithVersion("v0.25.1"))
+
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	restored := &Cluster{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if restored.Name != cluster.Name {
+		t.Errorf("Cluster name not as expected. Expected: %s, Actual: %s", cluster.Name, restored.Name)
+	}
+	if restored.Provider != cluster.Provider {
+		t.Errorf("Provider not as expected. Expected: %s, Actual: %s", cluster.Provider, restored.Provider)
+	}
+	if restored.Release != cluster.Release {
+		t.Errorf("Release not as expected. Expected: %v, Actual: %v", cluster.Release, restored.Release)
+	}
+	if restored.GetNamespace() != "org-test-org" {
+		t.Errorf("Namespace not as expected. Expected: org-test-org, Actual: %s", restored.GetNamespace())
+	}
+	if restored.ClusterApp.Organization.GetNamespace() != "org-test-org" {
+		t.Errorf("ClusterApp namespace not as expected. Expected: org-test-org, Actual: %s", restored.ClusterApp.Organization.GetNamespace())
+	}
+	if restored.ClusterApp.Values != cluster.ClusterApp.Values {
+		t.Errorf("ClusterApp values not as expected. Expected: %s, Actual: %s", cluster.ClusterApp.Values, restored.ClusterApp.Values)
+	}
+	if len(restored.appOverrides) != 1 || restored.appOverrides[0].InstallName != "example-cilium" || restored.appOverrides[0].Version != "v0.25.1" {
+		t.Errorf("App overrides not as expected. Actual: %v", restored.appOverrides)
+	}
+	if restored.versionOverrides["cluster-aws"] != (overrideVersion{Version: "v1.2.3", Catalog: "cluster-test"}) {
+		t.Errorf("Version overrides not as expected. Actual: %v", restored.versionOverrides)
+	}
+}
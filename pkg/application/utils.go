@@ -88,6 +88,20 @@ func getOverrideVersion(app string) (string, string, bool) {
 	return ov.Version, ov.Catalog, ok
 }
 
+// mergeOverrideVersions returns a copy of the existing version overrides with the provided overrides, in the format
+// `version[:catalog]` keyed by app name, added on top
+func mergeOverrideVersions(existing map[string]overrideVersion, overrides map[string]string) map[string]overrideVersion {
+	merged := map[string]overrideVersion{}
+	for appName, ov := range existing {
+		merged[appName] = ov
+	}
+	for appName, versionAndCatalog := range overrides {
+		version, catalog := parseVersionAndCatalog(strings.TrimSpace(versionAndCatalog))
+		merged[strings.TrimSpace(strings.ToLower(appName))] = overrideVersion{Version: version, Catalog: catalog}
+	}
+	return merged
+}
+
 // parseVersionAndCatalog splits a version string that may contain an optional
 // catalog suffix in the format "version:catalog" (e.g. "1.3.0-sha:cluster-test").
 func parseVersionAndCatalog(versionAndCatalog string) (version, catalog string) {
@@ -0,0 +1,65 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// writeArchive writes the contents of the directory, including the directory itself, to a gzipped tarball at the
+// given path
+func writeArchive(dir string, archivePath string) error {
+	file, err := os.Create(archivePath) // #nosec G304
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	base := filepath.Base(dir)
+	walkErr := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(base, relativePath))
+		if entry.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		contents, err := os.Open(filePath) // #nosec G304
+		if err != nil {
+			return err
+		}
+		defer contents.Close()
+		_, err = io.Copy(tarWriter, contents)
+		return err
+	})
+
+	// The writers must be closed in order to flush all data
+	return utilerrors.NewAggregate([]error{walkErr, tarWriter.Close(), gzipWriter.Close(), file.Close()})
+}
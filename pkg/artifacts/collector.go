@@ -0,0 +1,489 @@
+package artifacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	capi "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+const (
+	// DefaultLogLines is the number of lines collected from the end of the logs of each Pod
+	DefaultLogLines int64 = 1000
+
+	// ManifestFile is the name of the index of all collected files, found at the root of the output
+	ManifestFile = "manifest.json"
+)
+
+// The clusters files are collected from
+const (
+	SourceMC = "mc"
+	SourceWC = "wc"
+)
+
+// The types of collected files
+const (
+	TypeObject       = "object"
+	TypeEvents       = "events"
+	TypeLogs         = "logs"
+	TypePreviousLogs = "previous-logs"
+)
+
+// File is a single collected file listed in the Manifest
+type File struct {
+	// Path is the path of the file relative to the root of the output
+	Path string `json:"path"`
+	// Source is the cluster the file was collected from, either `mc` or `wc`
+	Source    string `json:"source"`
+	Type      string `json:"type"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// Manifest is the index of all files collected for a cluster, written to `manifest.json`
+type Manifest struct {
+	Cluster     string    `json:"cluster"`
+	Namespace   string    `json:"namespace"`
+	CollectedAt time.Time `json:"collectedAt"`
+	Files       []File    `json:"files"`
+	// Errors are any errors encountered while collecting, collection continues past them
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Target is the cluster to collect artifacts for
+type Target struct {
+	// Cluster is the Workload Cluster to collect artifacts for
+	Cluster *application.Cluster
+	// MC is the client for the Management Cluster the Workload Cluster is managed by
+	MC *client.Client
+	// WC is the client for the Workload Cluster. If nil, only artifacts from the MC are collected.
+	WC *client.Client
+	// ReleaseName is the name of the cluster's Release CR. If empty, any Releases labelled with the cluster's name are
+	// collected.
+	ReleaseName string
+}
+
+// Option is a function that configures a Collector
+type Option func(*Collector)
+
+// WithArchive writes the collected artifacts to a gzipped tarball (`<cluster name>-<timestamp>.tar.gz`) instead of
+// leaving them in a directory
+func WithArchive() Option {
+	return func(c *Collector) {
+		c.archive = true
+	}
+}
+
+// WithLogLines sets how many lines are collected from the end of the logs of each Pod. Defaults to `DefaultLogLines`.
+// If set to 0 the full logs are collected.
+func WithLogLines(lines int64) Option {
+	return func(c *Collector) {
+		c.logLines = lines
+	}
+}
+
+// Collector collects debugging artifacts from a Management Cluster and Workload Cluster into a directory
+type Collector struct {
+	dir      string
+	archive  bool
+	logLines int64
+
+	now func() time.Time
+}
+
+// NewCollector returns a new Collector that writes artifacts into the given directory, which is created if it doesn't
+// exist
+func NewCollector(dir string, opts ...Option) *Collector {
+	c := &Collector{
+		dir:      dir,
+		logLines: DefaultLogLines,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Collect gathers all artifacts for the target cluster into a new directory (`<cluster name>-<timestamp>`) within
+// the Collector's directory and returns the path to it, or to the tarball if `WithArchive` is used.
+//
+// Failing to collect an individual artifact doesn't stop the collection, the failure is instead recorded in the
+// `Errors` of the manifest. An error is only returned if the output can't be written.
+func (c *Collector) Collect(ctx context.Context, target Target) (string, error) {
+	if target.Cluster == nil || target.MC == nil {
+		return "", fmt.Errorf("a cluster and management cluster client are required to collect artifacts")
+	}
+
+	collectedAt := c.now()
+	name := fmt.Sprintf("%s-%s", target.Cluster.Name, collectedAt.UTC().Format("20060102-150405"))
+	root := filepath.Join(c.dir, name)
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create artifacts directory '%s': %w", root, err)
+	}
+
+	col := &collection{
+		root: root,
+		manifest: &Manifest{
+			Cluster:     target.Cluster.Name,
+			Namespace:   target.Cluster.GetNamespace(),
+			CollectedAt: collectedAt,
+			Files:       []File{},
+		},
+	}
+
+	logger.Log("Collecting artifacts for cluster '%s' into '%s'", target.Cluster.Name, root)
+	c.collectMC(ctx, col, target)
+	if target.WC != nil {
+		c.collectWC(ctx, col, target)
+	} else {
+		logger.Log("No client provided for workload cluster '%s', only collecting artifacts from the MC", target.Cluster.Name)
+	}
+
+	data, err := json.MarshalIndent(col.manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ManifestFile), data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if !c.archive {
+		logger.Log("Collected %d artifacts for cluster '%s' (%d errors)", len(col.manifest.Files), target.Cluster.Name, len(col.manifest.Errors))
+		return root, nil
+	}
+
+	archivePath := root + ".tar.gz"
+	if err := writeArchive(root, archivePath); err != nil {
+		return "", fmt.Errorf("failed to write archive '%s': %w", archivePath, err)
+	}
+	if err := os.RemoveAll(root); err != nil {
+		return "", fmt.Errorf("failed to remove artifacts directory '%s': %w", root, err)
+	}
+
+	logger.Log("Collected %d artifacts for cluster '%s' into '%s' (%d errors)", len(col.manifest.Files), target.Cluster.Name, archivePath, len(col.manifest.Errors))
+	return archivePath, nil
+}
+
+// collectMC collects the resources managing the cluster from its organization namespace on the MC
+func (c *Collector) collectMC(ctx context.Context, col *collection, target Target) {
+	mcClient := target.MC
+	clusterName := target.Cluster.Name
+	namespace := target.Cluster.GetNamespace()
+
+	cluster := &capi.Cluster{}
+	if err := mcClient.Get(ctx, cr.ObjectKey{Name: clusterName, Namespace: namespace}, cluster); err != nil {
+		col.addError("failed to get Cluster '%s/%s': %v", namespace, clusterName, err)
+	} else {
+		col.writeObject(SourceMC, mcClient, cluster)
+
+		for _, ref := range []capi.ContractVersionedObjectReference{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+			if !ref.IsDefined() {
+				continue
+			}
+			obj, err := external.GetObjectFromContractVersionedRef(ctx, mcClient, ref, namespace)
+			if err != nil {
+				col.addError("failed to get %s '%s/%s': %v", ref.Kind, namespace, ref.Name, err)
+				continue
+			}
+			col.writeObject(SourceMC, mcClient, obj)
+		}
+	}
+
+	clusterLabels := cr.MatchingLabels{capi.ClusterNameLabel: clusterName}
+	for _, list := range []cr.ObjectList{&capi.MachineDeploymentList{}, &capi.MachineSetList{}, &capi.MachinePoolList{}, &capi.MachineList{}} {
+		col.writeList(ctx, SourceMC, mcClient, list, nil, cr.InNamespace(namespace), clusterLabels)
+	}
+
+	belongsToCluster := func(obj cr.Object) bool {
+		return obj.GetName() == clusterName ||
+			strings.HasPrefix(obj.GetName(), clusterName+"-") ||
+			obj.GetLabels()["giantswarm.io/cluster"] == clusterName
+	}
+	col.writeList(ctx, SourceMC, mcClient, &applicationv1alpha1.AppList{}, belongsToCluster, cr.InNamespace(namespace))
+	col.writeList(ctx, SourceMC, mcClient, &helmv2.HelmReleaseList{}, belongsToCluster, cr.InNamespace(namespace))
+
+	// Releases are cluster scoped
+	releaseList := &releases.ReleaseList{}
+	if err := mcClient.List(ctx, releaseList, cr.MatchingLabels{"giantswarm.io/cluster": clusterName}); err != nil {
+		col.addError("failed to list Releases: %v", err)
+	}
+	for i := range releaseList.Items {
+		if target.ReleaseName == "" || releaseList.Items[i].Name == target.ReleaseName {
+			col.writeObject(SourceMC, mcClient, &releaseList.Items[i])
+		}
+	}
+	if target.ReleaseName != "" && !col.hasObject(SourceMC, "Release", "", target.ReleaseName) {
+		release := &releases.Release{}
+		if err := mcClient.Get(ctx, cr.ObjectKey{Name: target.ReleaseName}, release); err != nil {
+			col.addError("failed to get Release '%s': %v", target.ReleaseName, err)
+		} else {
+			col.writeObject(SourceMC, mcClient, release)
+		}
+	}
+
+	c.collectEvents(ctx, col, SourceMC, mcClient, namespace)
+
+	podList := &corev1.PodList{}
+	if err := mcClient.List(ctx, podList, cr.InNamespace(namespace)); err != nil {
+		col.addError("failed to list Pods in namespace '%s': %v", namespace, err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if belongsToCluster(pod) {
+			col.writeObject(SourceMC, mcClient, pod)
+			c.collectPodLogs(ctx, col, SourceMC, mcClient, pod)
+		}
+	}
+}
+
+// collectWC collects the Nodes, Pods, Events and logs from the WC
+func (c *Collector) collectWC(ctx context.Context, col *collection, target Target) {
+	wcClient := target.WC
+
+	nodeList := &corev1.NodeList{}
+	if err := wcClient.List(ctx, nodeList); err != nil {
+		col.addError("failed to list Nodes: %v", err)
+	}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		col.writeObject(SourceWC, wcClient, node)
+
+		events, err := wcClient.GetEventsForResource(ctx, node)
+		if err != nil {
+			col.addError("failed to get Events for Node '%s': %v", node.Name, err)
+			continue
+		}
+		col.writeYAML(File{
+			Path:   path.Join(SourceWC, "Node", node.Name+".events.yaml"),
+			Source: SourceWC,
+			Type:   TypeEvents,
+			Kind:   "Node",
+			Name:   node.Name,
+		}, cleanEvents(events.Items))
+	}
+
+	c.collectEvents(ctx, col, SourceWC, wcClient, "")
+
+	podList := &corev1.PodList{}
+	if err := wcClient.List(ctx, podList); err != nil {
+		col.addError("failed to list Pods: %v", err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		col.writeObject(SourceWC, wcClient, pod)
+		c.collectPodLogs(ctx, col, SourceWC, wcClient, pod)
+	}
+}
+
+// collectEvents writes all Events in the given namespace, or all namespaces if empty, grouped into a file per namespace
+func (c *Collector) collectEvents(ctx context.Context, col *collection, source string, kubeClient *client.Client, namespace string) {
+	eventList := &corev1.EventList{}
+	if err := kubeClient.List(ctx, eventList, cr.InNamespace(namespace)); err != nil {
+		col.addError("failed to list Events in %s: %v", source, err)
+		return
+	}
+
+	byNamespace := map[string][]corev1.Event{}
+	for _, event := range eventList.Items {
+		byNamespace[event.Namespace] = append(byNamespace[event.Namespace], event)
+	}
+
+	namespaces := []string{}
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		col.writeYAML(File{
+			Path:      path.Join(source, "events", ns+".yaml"),
+			Source:    source,
+			Type:      TypeEvents,
+			Namespace: ns,
+		}, cleanEvents(byNamespace[ns]))
+	}
+}
+
+// collectPodLogs writes the logs of the Pod's containers and, for any restarted containers, the logs of their previous
+// instance
+func (c *Collector) collectPodLogs(ctx context.Context, col *collection, source string, kubeClient *client.Client, pod *corev1.Pod) {
+	var tailLines *int64
+	if c.logLines > 0 {
+		tailLines = &c.logLines
+	}
+	logsDir := path.Join(source, "logs", pod.Namespace)
+
+	logs, err := kubeClient.GetLogs(ctx, pod, tailLines)
+	if err != nil {
+		col.addError("failed to get logs for Pod '%s/%s': %v", pod.Namespace, pod.Name, err)
+	} else {
+		col.writeFile(File{
+			Path:      path.Join(logsDir, pod.Name+".log"),
+			Source:    source,
+			Type:      TypeLogs,
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}, []byte(logs))
+	}
+
+	if !hasRestarted(pod) {
+		return
+	}
+
+	previousLogs, err := kubeClient.GetPreviousLogs(ctx, pod, tailLines)
+	if err != nil {
+		col.addError("failed to get previous logs for Pod '%s/%s': %v", pod.Namespace, pod.Name, err)
+		return
+	}
+	col.writeFile(File{
+		Path:      path.Join(logsDir, pod.Name+".previous.log"),
+		Source:    source,
+		Type:      TypePreviousLogs,
+		Kind:      "Pod",
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+	}, []byte(previousLogs))
+}
+
+func hasRestarted(pod *corev1.Pod) bool {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.RestartCount > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cleanEvents returns the Events without their managed fields to keep the output readable
+func cleanEvents(events []corev1.Event) []corev1.Event {
+	cleaned := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		event.ManagedFields = nil
+		cleaned = append(cleaned, event)
+	}
+	return cleaned
+}
+
+// collection is the output of a single run of a Collector
+type collection struct {
+	root     string
+	manifest *Manifest
+}
+
+func (c *collection) addError(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	logger.Log("Artifact collection: %s", message)
+	c.manifest.Errors = append(c.manifest.Errors, message)
+}
+
+func (c *collection) hasObject(source string, kind string, namespace string, name string) bool {
+	for _, file := range c.manifest.Files {
+		if file.Source == source && file.Type == TypeObject && file.Kind == kind && file.Namespace == namespace && file.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFile writes the data to the file's path within the output and records it in the manifest
+func (c *collection) writeFile(file File, data []byte) {
+	fullPath := filepath.Join(c.root, filepath.FromSlash(file.Path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		c.addError("failed to create directory for '%s': %v", file.Path, err)
+		return
+	}
+	if err := os.WriteFile(fullPath, data, 0o600); err != nil {
+		c.addError("failed to write '%s': %v", file.Path, err)
+		return
+	}
+	c.manifest.Files = append(c.manifest.Files, file)
+}
+
+func (c *collection) writeYAML(file File, value any) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		c.addError("failed to marshal '%s': %v", file.Path, err)
+		return
+	}
+	c.writeFile(file, data)
+}
+
+// writeObject writes the object as YAML to `<source>/<Kind>/<namespace>/<name>.yaml`
+func (c *collection) writeObject(source string, kubeClient *client.Client, obj cr.Object) {
+	obj, ok := obj.DeepCopyObject().(cr.Object)
+	if !ok {
+		return
+	}
+
+	// Objects returned from typed Lists don't include their kind
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		var err error
+		gvk, err = apiutil.GVKForObject(obj, kubeClient.Scheme())
+		if err != nil {
+			c.addError("failed to determine kind of '%s/%s': %v", obj.GetNamespace(), obj.GetName(), err)
+			return
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	obj.SetManagedFields(nil)
+
+	c.writeYAML(File{
+		Path:      path.Join(source, gvk.Kind, obj.GetNamespace(), obj.GetName()+".yaml"),
+		Source:    source,
+		Type:      TypeObject,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}, obj)
+}
+
+// writeList lists the objects and writes each of them that matches the optional filter
+func (c *collection) writeList(ctx context.Context, source string, kubeClient *client.Client, list cr.ObjectList, filter func(cr.Object) bool, opts ...cr.ListOption) {
+	if err := kubeClient.List(ctx, list, opts...); err != nil {
+		if meta.IsNoMatchError(err) {
+			// The resource type isn't installed in the cluster (e.g. MachinePools)
+			return
+		}
+		c.addError("failed to list %T: %v", list, err)
+		return
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		c.addError("failed to extract items of %T: %v", list, err)
+		return
+	}
+	for _, item := range items {
+		obj, ok := item.(cr.Object)
+		if !ok || (filter != nil && !filter(obj)) {
+			continue
+		}
+		c.writeObject(source, kubeClient, obj)
+	}
+}
@@ -0,0 +1,224 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	capi "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		capi.AddToScheme,
+		applicationv1alpha1.AddToScheme,
+		helmv2.AddToScheme,
+		releases.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("Failed to build scheme - %v", err)
+		}
+	}
+	return scheme
+}
+
+func newTestTarget(t *testing.T) Target {
+	scheme := newTestScheme(t)
+	cluster := &application.Cluster{
+		Name:         "t-abc",
+		Organization: organization.New("test"),
+	}
+	namespace := cluster.GetNamespace()
+
+	mcObjects := []cr.Object{
+		&capi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "t-abc", Namespace: namespace}},
+		&capi.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "t-abc-md00", Namespace: namespace, Labels: map[string]string{capi.ClusterNameLabel: "t-abc"}}},
+		&capi.MachineDeployment{ObjectMeta: metav1.ObjectMeta{Name: "t-other-md00", Namespace: namespace, Labels: map[string]string{capi.ClusterNameLabel: "t-other"}}},
+		&applicationv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "t-abc", Namespace: namespace}},
+		&applicationv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "t-abc-cilium", Namespace: namespace}},
+		&applicationv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "t-other", Namespace: namespace}},
+		&releases.Release{ObjectMeta: metav1.ObjectMeta{Name: "aws-30.0.0-abc", Labels: map[string]string{"giantswarm.io/cluster": "t-abc"}}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-1", Namespace: namespace}, Reason: "Failed", Type: corev1.EventTypeWarning},
+	}
+	wcObjects := []cr.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-2", Namespace: "kube-system"}, Reason: "BackOff", Type: corev1.EventTypeWarning},
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "event-3", Namespace: "default"}, Reason: "NodeNotReady", Type: corev1.EventTypeNormal,
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: "node-1"}},
+	}
+
+	return Target{
+		Cluster: cluster,
+		MC:      &client.Client{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcObjects...).Build()},
+		WC: &client.Client{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(wcObjects...).
+			// Field selectors used by `GetEventsForResource`
+			WithIndex(&corev1.Event{}, "involvedObject.name", func(obj cr.Object) []string {
+				return []string{obj.(*corev1.Event).InvolvedObject.Name}
+			}).
+			WithIndex(&corev1.Event{}, "involvedObject.kind", func(obj cr.Object) []string {
+				return []string{obj.(*corev1.Event).InvolvedObject.Kind}
+			}).
+			Build()},
+	}
+}
+
+func readManifest(t *testing.T, data []byte) *Manifest {
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		t.Fatalf("Failed to parse manifest - %v", err)
+	}
+	return manifest
+}
+
+func TestCollect(t *testing.T) {
+	dir := t.TempDir()
+	collector := NewCollector(dir)
+	collector.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	outputPath, err := collector.Collect(context.Background(), newTestTarget(t))
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if outputPath != filepath.Join(dir, "t-abc-20260102-030405") {
+		t.Errorf("Output path not as expected. Actual: %s", outputPath)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputPath, ManifestFile)) // #nosec G304
+	if err != nil {
+		t.Fatalf("Expected manifest to be written - %v", err)
+	}
+	manifest := readManifest(t, data)
+	if len(manifest.Errors) != 0 {
+		t.Errorf("Not expecting any collection errors. Actual: %v", manifest.Errors)
+	}
+
+	expectedFiles := []string{
+		"mc/Cluster/org-test/t-abc.yaml",
+		"mc/MachineDeployment/org-test/t-abc-md00.yaml",
+		"mc/App/org-test/t-abc.yaml",
+		"mc/App/org-test/t-abc-cilium.yaml",
+		"mc/Release/aws-30.0.0-abc.yaml",
+		"mc/events/org-test.yaml",
+		"wc/Node/node-1.yaml",
+		"wc/Node/node-1.events.yaml",
+		"wc/events/kube-system.yaml",
+		"wc/events/default.yaml",
+	}
+	if len(manifest.Files) != len(expectedFiles) {
+		t.Errorf("Expected %d files in manifest. Actual: %v", len(expectedFiles), manifest.Files)
+	}
+	for _, expected := range expectedFiles {
+		found := false
+		for _, file := range manifest.Files {
+			if file.Path == expected {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected '%s' to be listed in the manifest", expected)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, expected)); err != nil {
+			t.Errorf("Expected '%s' to be written - %v", expected, err)
+		}
+	}
+
+	nodeEvents, err := os.ReadFile(filepath.Join(outputPath, "wc/Node/node-1.events.yaml")) // #nosec G304
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if !strings.Contains(string(nodeEvents), "NodeNotReady") {
+		t.Errorf("Expected the Node's events to be included. Actual: %s", nodeEvents)
+	}
+
+	clusterYAML, err := os.ReadFile(filepath.Join(outputPath, "mc/Cluster/org-test/t-abc.yaml")) // #nosec G304
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if !strings.Contains(string(clusterYAML), "kind: Cluster") {
+		t.Errorf("Expected the object kind to be included. Actual: %s", clusterYAML)
+	}
+}
+
+func TestCollect_Archive(t *testing.T) {
+	dir := t.TempDir()
+	target := newTestTarget(t)
+	target.WC = nil
+
+	outputPath, err := NewCollector(dir, WithArchive()).Collect(context.Background(), target)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if !strings.HasSuffix(outputPath, ".tar.gz") {
+		t.Errorf("Expected a tarball to be returned. Actual: %s", outputPath)
+	}
+	if _, err := os.Stat(strings.TrimSuffix(outputPath, ".tar.gz")); !os.IsNotExist(err) {
+		t.Errorf("Expected artifacts directory to be removed")
+	}
+
+	archive, err := os.Open(outputPath) // #nosec G304
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	defer archive.Close()
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		if filepath.Base(header.Name) == ManifestFile {
+			data, err := io.ReadAll(tarReader)
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			manifest = readManifest(t, data)
+		}
+	}
+
+	if manifest == nil {
+		t.Fatalf("Expected the manifest to be included in the archive")
+	}
+	for _, file := range manifest.Files {
+		if file.Source != SourceMC {
+			t.Errorf("Not expecting any WC artifacts without a WC client. Actual: %v", file)
+		}
+	}
+}
+
+func TestCollect_RequiresCluster(t *testing.T) {
+	_, err := NewCollector(t.TempDir()).Collect(context.Background(), Target{})
+	if err == nil {
+		t.Errorf("Expected an error to be returned")
+	}
+}
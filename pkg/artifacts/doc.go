@@ -0,0 +1,51 @@
+// package artifacts provides a must-gather style Collector that dumps the state of a Workload Cluster, and the
+// resources managing it on the Management Cluster, into a structured directory (or tarball) for later debugging.
+//
+// The following are collected:
+//
+//   - From the MC (within the cluster's organization namespace): the CAPI Cluster along with its infrastructure and
+//     control plane resources, MachineDeployments, MachineSets, MachinePools and Machines, the cluster's App and
+//     HelmRelease CRs, its Release CR and all Events in the namespace as well as the logs of the cluster's Pods
+//     (e.g. the cluster's app-operator).
+//   - From the WC: all Nodes along with their Events, all Pods, all Events and the logs of all Pods, including the
+//     logs of the previous instance of any restarted containers.
+//
+// Collection continues past any errors, which are logged and recorded in the manifest. Each collected file is listed
+// in a `manifest.json` index at the root of the output.
+//
+// # Example
+//
+//	collector := artifacts.NewCollector("/tmp/artifacts", artifacts.WithArchive())
+//	path, err := collector.Collect(ctx, artifacts.Target{
+//		Cluster: cluster,
+//		MC:      framework.MC(),
+//		WC:      wcClient,
+//	})
+//
+// # Example using a Framework
+//
+//	path, err := framework.CollectArtifacts(ctx, cluster, "/tmp/artifacts")
+//
+// # Example as a failure handler
+//
+//	Eventually(wait.IsAllAppDeployed(ctx, framework.MC(), appNamespacedNames)).
+//		WithTimeout(timeout).
+//		Should(
+//			BeTrue(),
+//			failurehandler.CollectArtifacts(framework, cluster, "/tmp/artifacts"),
+//		)
+//
+// # Output layout
+//
+//	<dir>/<cluster name>-<timestamp>/
+//		manifest.json
+//		mc/<Kind>/<namespace>/<name>.yaml
+//		mc/events/<namespace>.yaml
+//		mc/logs/<namespace>/<pod>.log
+//		wc/<Kind>/<namespace>/<name>.yaml
+//		wc/Node/<name>.yaml
+//		wc/Node/<name>.events.yaml
+//		wc/events/<namespace>.yaml
+//		wc/logs/<namespace>/<pod>.log
+//		wc/logs/<namespace>/<pod>.previous.log
+package artifacts
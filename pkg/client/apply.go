@@ -0,0 +1,201 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// DefaultFieldManager is the field manager used by `ApplyManifests` if none is provided
+const DefaultFieldManager = "clustertest"
+
+// ApplyManifestsOptions are the options available when applying manifests with `ApplyManifests`
+type ApplyManifestsOptions struct {
+	// FieldManager is the name of the field manager the manifests are applied as. Defaults to `DefaultFieldManager`.
+	FieldManager string
+	// Namespace is the namespace used for namespaced objects that don't specify one. Defaults to `default`.
+	Namespace string
+	// ForceConflicts takes ownership of fields owned by other field managers instead of failing. Defaults to true.
+	ForceConflicts bool
+}
+
+// ApplyManifestsOption is a function that configures ApplyManifestsOptions
+type ApplyManifestsOption func(*ApplyManifestsOptions)
+
+// WithFieldManager sets the name of the field manager the manifests are applied as
+func WithFieldManager(fieldManager string) ApplyManifestsOption {
+	return func(opts *ApplyManifestsOptions) {
+		opts.FieldManager = fieldManager
+	}
+}
+
+// WithDefaultNamespace sets the namespace used for namespaced objects that don't specify one
+func WithDefaultNamespace(namespace string) ApplyManifestsOption {
+	return func(opts *ApplyManifestsOptions) {
+		opts.Namespace = namespace
+	}
+}
+
+// WithForceConflicts sets whether fields owned by other field managers are taken over or cause the apply to fail
+func WithForceConflicts(force bool) ApplyManifestsOption {
+	return func(opts *ApplyManifestsOptions) {
+		opts.ForceConflicts = force
+	}
+}
+
+// ApplyManifests server-side applies all the objects found in the provided (multi-document) YAML or JSON manifests,
+// in the order they are defined, and returns the applied objects as returned by the API server (e.g. for later cleanup).
+//
+// If `templateVars` isn't nil the manifests are first rendered as a Go template with `templateVars` as the data.
+// Each object's kind is resolved using the cluster's API discovery so any kind known to the cluster, including custom
+// resources, can be applied. Lists (`kind: List`) are applied item by item.
+//
+// Applying stops at the first object that fails, returning the objects applied so far along with the error.
+// Any create hooks (see `AddCreateHook`) are called for the objects that didn't previously exist.
+//
+// Example:
+//
+//	objects, err := wcClient.ApplyManifests(ctx, strings.NewReader(manifests), map[string]string{"Replicas": "3"},
+//		client.WithDefaultNamespace("test"),
+//	)
+func (c *Client) ApplyManifests(ctx context.Context, manifests io.Reader, templateVars any, opts ...ApplyManifestsOption) ([]*unstructured.Unstructured, error) {
+	options := &ApplyManifestsOptions{
+		FieldManager:   DefaultFieldManager,
+		Namespace:      "default",
+		ForceConflicts: true,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	objects, err := decodeManifests(manifests, templateVars)
+	if err != nil {
+		return nil, err
+	}
+
+	applyOpts := []cr.ApplyOption{cr.FieldOwner(options.FieldManager)}
+	if options.ForceConflicts {
+		applyOpts = append(applyOpts, cr.ForceOwnership)
+	}
+
+	applied := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return applied, fmt.Errorf("failed to resolve kind of %s '%s' - %v", gvk.Kind, obj.GetName(), err)
+		}
+
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(options.Namespace)
+			}
+		} else {
+			obj.SetNamespace("")
+		}
+
+		// Server-side apply doesn't go through `Create` so we need to check if the object is new ourselves
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(gvk)
+		err = c.Get(ctx, cr.ObjectKeyFromObject(obj), existing)
+		if cr.IgnoreNotFound(err) != nil {
+			return applied, fmt.Errorf("failed to get %s '%s' - %w", gvk.Kind, cr.ObjectKeyFromObject(obj), err)
+		}
+		created := err != nil
+
+		logger.Log("Applying %s '%s'", gvk.Kind, cr.ObjectKeyFromObject(obj))
+		if err := c.Apply(ctx, cr.ApplyConfigurationFromUnstructured(obj), applyOpts...); err != nil {
+			return applied, fmt.Errorf("failed to apply %s '%s' - %w", gvk.Kind, cr.ObjectKeyFromObject(obj), err)
+		}
+		applied = append(applied, obj)
+
+		if created {
+			for _, hook := range c.getCreateHooks() {
+				hook(c, obj)
+			}
+		}
+	}
+
+	return applied, nil
+}
+
+// ApplyManifestsFromFile is like `ApplyManifests` but reads the manifests from the file at the given path
+func (c *Client) ApplyManifestsFromFile(ctx context.Context, path string, templateVars any, opts ...ApplyManifestsOption) ([]*unstructured.Unstructured, error) {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifests file - %v", err)
+	}
+	defer file.Close()
+
+	return c.ApplyManifests(ctx, file, templateVars, opts...)
+}
+
+// decodeManifests renders the manifests with the template vars, if provided, and decodes every object they contain
+func decodeManifests(manifests io.Reader, templateVars any) ([]*unstructured.Unstructured, error) {
+	data, err := io.ReadAll(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests - %v", err)
+	}
+
+	if templateVars != nil {
+		tmpl, err := template.New("manifests").Option("missingkey=error").Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifests template - %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, templateVars); err != nil {
+			return nil, fmt.Errorf("failed to render manifests template - %v", err)
+		}
+		data = buf.Bytes()
+	}
+
+	objects := []*unstructured.Unstructured{}
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifests - %v", err)
+		}
+		// Skip empty documents, e.g. those only containing comments
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		if !obj.IsList() {
+			objects = append(objects, obj)
+			continue
+		}
+		err = obj.EachListItem(func(item runtime.Object) error {
+			objects = append(objects, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode list - %v", err)
+		}
+	}
+
+	for _, obj := range objects {
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("failed to decode manifests - object '%s' is missing apiVersion or kind", obj.GetName())
+		}
+	}
+
+	return objects, nil
+}
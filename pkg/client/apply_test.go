@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testManifests = `
+# A comment only document
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{ .Namespace }}
+  namespace: ignored
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: example
+data:
+  replicas: "{{ .Replicas }}"
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: from-list
+    namespace: other
+`
+
+func TestDecodeManifests(t *testing.T) {
+	objects, err := decodeManifests(strings.NewReader(testManifests), map[string]string{"Namespace": "test", "Replicas": "3"})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	expected := []string{"Namespace/test", "ConfigMap/example", "ConfigMap/from-list"}
+	if len(objects) != len(expected) {
+		t.Fatalf("Expected %d objects. Actual: %d", len(expected), len(objects))
+	}
+	for i, obj := range objects {
+		if actual := obj.GetKind() + "/" + obj.GetName(); actual != expected[i] {
+			t.Errorf("Object not as expected. Expected: %s, Actual: %s", expected[i], actual)
+		}
+	}
+
+	t.Run("missing template var", func(t *testing.T) {
+		_, err := decodeManifests(strings.NewReader(testManifests), map[string]string{"Namespace": "test"})
+		if err == nil {
+			t.Errorf("Expected an error to be returned")
+		}
+	})
+
+	t.Run("no templating", func(t *testing.T) {
+		objects, err := decodeManifests(strings.NewReader("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: example\ndata:\n  values: '{{ .Values }}'\n"), nil)
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		if actual, _, _ := unstructured.NestedString(objects[0].Object, "data", "values"); actual != "{{ .Values }}" {
+			t.Errorf("Expected manifests not to be templated. Actual: %s", actual)
+		}
+	})
+
+	t.Run("missing kind", func(t *testing.T) {
+		_, err := decodeManifests(strings.NewReader("apiVersion: v1\nmetadata:\n  name: example\n"), nil)
+		if err == nil {
+			t.Errorf("Expected an error to be returned")
+		}
+	})
+}
+
+func TestApplyManifests(t *testing.T) {
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	c := &Client{Client: fake.NewClientBuilder().WithRESTMapper(restMapper).Build()}
+	ctx := context.Background()
+
+	created := []string{}
+	c.AddCreateHook(func(_ *Client, obj cr.Object) {
+		created = append(created, obj.GetName())
+	})
+
+	applied, err := c.ApplyManifests(ctx, strings.NewReader(testManifests), map[string]string{"Namespace": "test", "Replicas": "3"}, WithDefaultNamespace("test"))
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(applied) != 3 {
+		t.Fatalf("Expected 3 applied objects. Actual: %d", len(applied))
+	}
+	if applied[0].GetNamespace() != "" {
+		t.Errorf("Expected the namespace of cluster scoped objects to be removed. Actual: %s", applied[0].GetNamespace())
+	}
+	if strings.Join(created, ",") != "test,example,from-list" {
+		t.Errorf("Expected create hooks to be called for each new object. Actual: %v", created)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "example", Namespace: "test"}, configMap); err != nil {
+		t.Fatalf("Expected ConfigMap to be applied to the default namespace - %v", err)
+	}
+	if configMap.Data["replicas"] != "3" {
+		t.Errorf("Expected ConfigMap data to be templated. Actual: %v", configMap.Data)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "from-list", Namespace: "other"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("Expected ConfigMap from list to be applied - %v", err)
+	}
+
+	// Re-applying updates the existing objects
+	_, err = c.ApplyManifests(ctx, strings.NewReader(testManifests), map[string]string{"Namespace": "test", "Replicas": "5"}, WithDefaultNamespace("test"))
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "example", Namespace: "test"}, configMap); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if configMap.Data["replicas"] != "5" {
+		t.Errorf("Expected ConfigMap to be updated. Actual: %v", configMap.Data)
+	}
+	if len(created) != 3 {
+		t.Errorf("Expected create hooks to not be called for existing objects. Actual: %v", created)
+	}
+
+	t.Run("unknown kind", func(t *testing.T) {
+		applied, err := c.ApplyManifests(ctx, strings.NewReader("apiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: example\n"), nil)
+		if err == nil {
+			t.Errorf("Expected an error to be returned")
+		}
+		if len(applied) != 0 {
+			t.Errorf("Expected no objects to be applied. Actual: %d", len(applied))
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 
 	certmanager "github.com/cert-manager/cert-manager/pkg/api"
 	helm "github.com/fluxcd/helm-controller/api/v2"
@@ -35,15 +36,34 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/giantswarm/clustertest/v5/pkg/application"
-	"github.com/giantswarm/clustertest/v5/pkg/logger"
 )
 
 // Client extends the client from controller-runtime
 type Client struct {
 	cr.Client
 
-	config      *rest.Config
-	clusterName string
+	config              *rest.Config
+	configMu            sync.RWMutex
+	refresher           *refreshTransport
+	clusterName         string
+	createHooks         createHooks
+	kubeconfigProviders kubeconfigProviders
+	versionOverrides    map[string]string
+}
+
+// Options are the options available when creating a new Client
+type Options struct {
+	// Context is the kubeconfig context to use. Defaults to the current context of the kubeconfig.
+	Context string
+	// QPS overrides the client-go QPS. Defaults to the `E2E_CLIENT_QPS` env var or 50.
+	QPS float32
+	// Burst overrides the client-go Burst. Defaults to the `E2E_CLIENT_BURST` env var or 100.
+	Burst int
+	// Refresh is used to get new credentials when the current ones are rejected. See `SetRefreshFunc`.
+	Refresh RefreshFunc
+	// VersionOverrides are app versions used by `DeployApp` and `DeleteApp` for apps without an explicit version, keyed
+	// by app name with values in the format `version[:catalog]`. See `application.Application.WithVersionOverrides`.
+	VersionOverrides map[string]string
 }
 
 // New creates a new Kubernetes client for the provided kubeconfig file
@@ -78,7 +98,7 @@ func NewFromRawKubeconfig(kubeconfig string) (*Client, error) {
 		return nil, fmt.Errorf("failed to get TLS server name - %v", err)
 	}
 
-	return newClient(restConfig, clusterName)
+	return newClient(restConfig, clusterName, Options{})
 }
 
 // NewFromSecret creates a new Kubernetes client from a cluster kubeconfig found in a secret on the MC.
@@ -103,6 +123,16 @@ func NewFromSecret(ctx context.Context, kubeClient *Client, clusterName string,
 // The creation of the client doesn't confirm connectivity to the cluster and REST discovery is set to lazy discovery
 // so the client can be created while the cluster is still being set up.
 func NewWithContext(kubeconfigPath string, contextName string) (*Client, error) {
+	return NewWithOptions(kubeconfigPath, Options{Context: contextName})
+}
+
+// NewWithOptions creates a new Kubernetes client for the provided kubeconfig file configured with the provided Options
+//
+// The client is an extension of the client from controller-runtime and provides some additional helper functions.
+// The creation of the client doesn't confirm connectivity to the cluster and REST discovery is set to lazy discovery
+// so the client can be created while the cluster is still being set up.
+func NewWithOptions(kubeconfigPath string, opts Options) (*Client, error) {
+	contextName := opts.Context
 	if kubeconfigPath == "" {
 		return nil, fmt.Errorf("a kubeconfig file must be provided")
 	}
@@ -134,24 +164,72 @@ func NewWithContext(kubeconfigPath string, contextName string) (*Client, error)
 		return nil, fmt.Errorf("failed to create config - %v", err)
 	}
 
-	return newClient(cfg, clusterName)
+	return newClient(cfg, clusterName, opts)
+}
+
+// NewFromRawKubeconfigWithOptions is like NewFromRawKubeconfig but takes in the raw bytes of a Kubeconfig along with
+// Options to configure the client instead of relying on env vars.
+//
+// The client is an extension of the client from controller-runtime and provides some additional helper functions.
+// The creation of the client doesn't confirm connectivity to the cluster and REST discovery is set to lazy discovery
+// so the client can be created while the cluster is still being set up.
+func NewFromRawKubeconfigWithOptions(kubeconfig []byte, opts Options) (*Client, error) {
+	clusterName, err := getClusterNameFromKubeConfig(kubeconfig, opts.Context)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster name - %v", err)
+	}
+	restConfig, err := restConfigFromRawKubeconfig(kubeconfig, opts.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(restConfig, clusterName, opts)
+}
+
+// restConfigFromRawKubeconfig returns the rest.Config for the given context of the provided raw kubeconfig.
+// If no context is provided the current context is used.
+func restConfigFromRawKubeconfig(kubeconfig []byte, contextName string) (*rest.Config, error) {
+	rawConfig, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig - %v", err)
+	}
+	restConfig, err := clientcmd.NewNonInteractiveClientConfig(*rawConfig, contextName, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest config - %v", err)
+	}
+	if restConfig.ServerName == "" {
+		restConfig.ServerName, err = getTLSServerNameFromKubeConfig(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS server name - %v", err)
+		}
+	}
+
+	return restConfig, nil
 }
 
-func newClient(config *rest.Config, clusterName string) (*Client, error) {
+func newClient(config *rest.Config, clusterName string, opts Options) (*Client, error) {
 	// Tune QPS/Burst and install a retrying transport before the HTTP client is
 	// built so all requests made through this client benefit from it.
-	applyClientResilience(config)
+	applyClientResilience(config, opts)
+
+	// TLS and authentication are handled by a transport that can be rebuilt with new credentials if they are rejected
+	refresher, err := newRefreshTransport(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport - %v", err)
+	}
+	refresher.setRefreshFunc(opts.Refresh)
+	connConfig := connectionConfig(config, refresher)
 
-	httpClient, err := rest.HTTPClientFor(config)
+	httpClient, err := rest.HTTPClientFor(connConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http client - %v", err)
 	}
-	mapper, err := apiutil.NewDynamicRESTMapper(config, httpClient)
+	mapper, err := apiutil.NewDynamicRESTMapper(connConfig, httpClient)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new dynamic client - %v", err)
 	}
 
-	client, err := cr.New(config, cr.Options{Scheme: scheme.Scheme, Mapper: mapper})
+	client, err := cr.NewWithWatch(connConfig, cr.Options{Scheme: scheme.Scheme, Mapper: mapper, HTTPClient: httpClient})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new client - %v", err)
 	}
@@ -167,11 +245,21 @@ func newClient(config *rest.Config, clusterName string) (*Client, error) {
 	_ = sourcev1beta2.AddToScheme(client.Scheme())
 	_ = gatewayv1.AddToScheme(client.Scheme())
 
-	return &Client{
-		Client:      client,
-		config:      config,
-		clusterName: clusterName,
-	}, nil
+	c := &Client{
+		config:           config,
+		refresher:        refresher,
+		clusterName:      clusterName,
+		versionOverrides: opts.VersionOverrides,
+	}
+	// Wrapped up front so CreateHooks can be added later without swapping the client while it's in use
+	c.Client = &hookedClient{Client: client, owner: c}
+	refresher.onRefresh = func(refreshed *rest.Config) {
+		// Keep the same client-side settings with the new connection details
+		applyClientResilience(refreshed, opts)
+		c.setRestConfig(refreshed)
+	}
+
+	return c, nil
 }
 
 // getClusterNameFromKubeConfig gets the cluster name of the cluster selected for the provided context.
@@ -259,24 +347,6 @@ func (c *Client) CheckConnection() error {
 	return err
 }
 
-// GetClusterKubeConfig retrieves the kubeconfig from the secret associated with the provided cluster name.
-func (c *Client) GetClusterKubeConfig(ctx context.Context, clusterName string, clusterNamespace string) (string, error) {
-	// Get Teleport kubeconfig.
-	kubeconfig, err := c.getTeleportKubeConfig(ctx, clusterName, "giantswarm")
-	if err != nil {
-		logger.Log("Failed to get Teleport kubeconfig, falling back to CAPI kubeconfig: %v", err)
-
-		// Get CAPI kubeconfig.
-		kubeconfig, err = c.getCAPIKubeConfig(ctx, clusterName, clusterNamespace)
-		if err != nil {
-			return "", fmt.Errorf("failed to get CAPI kubeconfig: %w", err)
-		}
-	}
-
-	// Return kubeconfig.
-	return kubeconfig, nil
-}
-
 // getTeleportKubeConfig retrieves the kubeconfig from the secret that is created by Teleport tbot on the MC.
 func (c *Client) getTeleportKubeConfig(ctx context.Context, clusterName string, clusterNamespace string) (string, error) {
 	// Concatenate kubeconfig Secret name.
@@ -313,7 +383,7 @@ func (c *Client) getTeleportKubeConfig(ctx context.Context, clusterName string,
 
 // IsTeleportKubeconfig checks if the kubeconfig being used is one provided by Teleport or not
 func (c *Client) IsTeleportKubeconfig() bool {
-	return strings.Contains(c.config.ServerName, "teleport")
+	return strings.Contains(c.restConfig().ServerName, "teleport")
 }
 
 // getCAPIKubeConfig retrieves the Kubeconfig from the secret that is created by CAPI controllers on the MC
@@ -429,7 +499,7 @@ func (c *Client) getHelmClient(releaseNamespace string) (helmclient.Client, erro
 		Options: &helmclient.Options{
 			Namespace: releaseNamespace,
 		},
-		RestConfig: c.config,
+		RestConfig: c.restConfig(),
 	}
 
 	helmClient, err := helmclient.NewClientFromRestConf(opt)
@@ -464,7 +534,7 @@ func (c *Client) CreateOrUpdate(ctx context.Context, obj cr.Object) error {
 // DeployApp takes an Application and applies its manifests to the cluster in the correct order,
 // ensuring the ConfigMap is made available first.
 func (c *Client) DeployApp(ctx context.Context, app application.Application) error {
-	appCR, configMap, err := app.Build()
+	appCR, configMap, err := app.WithVersionOverrides(c.versionOverrides).Build()
 	if err != nil {
 		return err
 	}
@@ -487,7 +557,7 @@ func (c *Client) DeployAppManifests(ctx context.Context, appCR *applicationv1alp
 
 // DeleteApp removes an App CR and its ConfigMap from the cluster
 func (c *Client) DeleteApp(ctx context.Context, app application.Application) error {
-	appCR, configMap, err := app.Build()
+	appCR, configMap, err := app.WithVersionOverrides(c.versionOverrides).Build()
 	if err != nil {
 		return err
 	}
@@ -504,7 +574,7 @@ func (c *Client) DeleteApp(ctx context.Context, app application.Application) err
 
 // GetAPIServerEndpoint returns the full URL for the API server
 func (c *Client) GetAPIServerEndpoint() string {
-	return c.config.Host
+	return c.restConfig().Host
 }
 
 // GetClusterName returns the friendly name of the Cluster from the KubeConfig
@@ -9,10 +9,16 @@ import (
 	"testing"
 	"time"
 
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/kind/pkg/cluster"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
 )
 
 var kindKubeconfig string
@@ -85,6 +91,38 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithOptions(t *testing.T) {
+	kubeconfigPath := path.Clean("./test_data/mock_kubeconfig.yaml")
+	kubeconfig, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to read mock kubeconfig - %v", err)
+	}
+
+	opts := Options{Context: "kind-example-cluster", QPS: 7, Burst: 9}
+
+	fromFile, err := NewWithOptions(kubeconfigPath, opts)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	fromBytes, err := NewFromRawKubeconfigWithOptions(kubeconfig, opts)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	for _, c := range []*Client{fromFile, fromBytes} {
+		if c.GetClusterName() != "kind-example-cluster" {
+			t.Errorf("Cluster name not as expected. Expected: kind-example-cluster, Actual: %s", c.GetClusterName())
+		}
+		if c.config.QPS != 7 || c.config.Burst != 9 {
+			t.Errorf("QPS/Burst not as expected. Expected: 7/9, Actual: %v/%d", c.config.QPS, c.config.Burst)
+		}
+	}
+
+	if _, err := NewFromRawKubeconfigWithOptions(kubeconfig, Options{Context: "not-a-context"}); err == nil {
+		t.Errorf("Was expecting an error for an unknown context")
+	}
+}
+
 func TestCheckConnection_Successful(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -163,3 +201,27 @@ func TestGetPodsForDeployment(t *testing.T) {
 		t.Errorf("Was expecting some pods to be returned")
 	}
 }
+
+func TestDeployApp_VersionOverrides(t *testing.T) {
+	ctx := context.Background()
+	c := &Client{
+		Client:           fake.NewClientBuilder().Build(),
+		versionOverrides: map[string]string{"cilium": "v1.2.3"},
+	}
+
+	app := application.New("cilium", "cilium").WithOrganization(*organization.New("test"))
+	if err := c.DeployApp(ctx, *app); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	appCR := &applicationv1alpha1.App{}
+	if err := c.Get(ctx, cr.ObjectKey{Name: "cilium", Namespace: app.GetNamespace()}, appCR); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if appCR.Spec.Version != "v1.2.3" {
+		t.Errorf("Version not as expected. Expected: v1.2.3, Actual: %s", appCR.Spec.Version)
+	}
+	if app.Version != "" {
+		t.Errorf("Expected the provided Application to be left unchanged. Actual version: %s", app.Version)
+	}
+}
@@ -0,0 +1,204 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// CopyToPod copies the local file or directory at `srcPath` to `destPath` within a container of a pod, in the same way
+// as `kubectl cp`. The contents are streamed as a tar archive so `tar` must be available in the container.
+//
+// Example:
+//
+//	err := client.CopyToPod(ctx, "my-pod", "default", "app", "./testdata/fixtures", "/tmp/fixtures")
+func (c *Client) CopyToPod(ctx context.Context, podName, namespace, containerName, srcPath, destPath string) error {
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("failed to copy '%s' to pod - %v", srcPath, err)
+	}
+
+	destDir, destName := splitPodPath(destPath)
+	logger.Log("Copying '%s' to '%s' in container '%s' in pod '%s'", srcPath, destPath, containerName, podName)
+
+	reader, writer := io.Pipe()
+	tarErr := make(chan error, 1)
+	go func() {
+		err := writeTar(writer, srcPath, destName)
+		_ = writer.CloseWithError(err)
+		tarErr <- err
+	}()
+
+	err := c.execWithStderr(ctx, podName, namespace, containerName, []string{"tar", "-xmf", "-", "-C", destDir}, reader, io.Discard)
+	// Unblock the archive writer if the command finished without reading all of it
+	_ = reader.Close()
+	if writeErr := <-tarErr; writeErr != nil && writeErr != io.ErrClosedPipe {
+		return fmt.Errorf("failed to archive '%s' - %v", srcPath, writeErr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy '%s' to pod - %w", srcPath, err)
+	}
+
+	return nil
+}
+
+// CopyFromPod copies the file or directory at `srcPath` within a container of a pod to the local `destPath`, in the
+// same way as `kubectl cp`. The contents are streamed as a tar archive so `tar` must be available in the container.
+//
+// Symlinks aren't copied and any entries that would be written outside of `destPath` result in an error.
+//
+// Example:
+//
+//	err := client.CopyFromPod(ctx, "my-pod", "default", "app", "/tmp/results", "./results")
+func (c *Client) CopyFromPod(ctx context.Context, podName, namespace, containerName, srcPath, destPath string) error {
+	srcDir, srcName := splitPodPath(srcPath)
+	logger.Log("Copying '%s' from container '%s' in pod '%s' to '%s'", srcPath, containerName, podName, destPath)
+
+	reader, writer := io.Pipe()
+	go func() {
+		err := c.execWithStderr(ctx, podName, namespace, containerName, []string{"tar", "cf", "-", "-C", srcDir, srcName}, nil, writer)
+		_ = writer.CloseWithError(err)
+	}()
+	// Stop the command if extracting fails part way through
+	defer reader.Close()
+
+	if err := extractTar(reader, srcName, destPath); err != nil {
+		return fmt.Errorf("failed to copy '%s' from pod - %w", srcPath, err)
+	}
+
+	return nil
+}
+
+// splitPodPath returns the directory and base name of a path within a container
+func splitPodPath(podPath string) (string, string) {
+	podPath = path.Clean(podPath)
+	dir, name := path.Split(podPath)
+	if dir == "" {
+		dir = "."
+	}
+	return dir, name
+}
+
+// writeTar writes the file or directory at `srcPath` to a tar archive with its contents named under `name`
+func writeTar(w io.Writer, srcPath string, name string) error {
+	tarWriter := tar.NewWriter(w)
+
+	err := filepath.WalkDir(srcPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(srcPath, filePath)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(relativePath))
+		if entry.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(filePath) // #nosec G304
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+// extractTar extracts the entries named under `prefix` from a tar archive to `destPath`
+func extractTar(r io.Reader, prefix string, destPath string) error {
+	tarReader := tar.NewReader(r)
+	extracted := false
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		var relativePath string
+		switch {
+		case name == prefix:
+			relativePath = "."
+		case strings.HasPrefix(name, prefix+"/"):
+			relativePath = strings.TrimPrefix(name, prefix+"/")
+		default:
+			continue
+		}
+		if relativePath == ".." || strings.HasPrefix(relativePath, "../") {
+			return fmt.Errorf("archive entry '%s' is outside of the copied path", header.Name)
+		}
+		target := filepath.Join(destPath, filepath.FromSlash(relativePath))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o750); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(header.Mode&0o777)) // #nosec G304 G115
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, tarReader) // #nosec G110
+			closeErr := file.Close()
+			if err != nil {
+				return err
+			}
+			if closeErr != nil {
+				return closeErr
+			}
+		default:
+			logger.Log("Skipping '%s' as only files and directories are copied", header.Name)
+			continue
+		}
+		extracted = true
+	}
+
+	if !extracted {
+		return fmt.Errorf("no files found to copy")
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestTarRoundTrip(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "fixtures")
+	files := map[string]string{
+		"a.txt":        "hello",
+		"nested/b.txt": "world",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(srcDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("directory", func(t *testing.T) {
+		archive := &bytes.Buffer{}
+		if err := writeTar(archive, srcDir, "copied"); err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+
+		destDir := filepath.Join(t.TempDir(), "results")
+		if err := extractTar(archive, "copied", destDir); err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+
+		for name, expected := range files {
+			actual, err := os.ReadFile(filepath.Join(destDir, name)) // #nosec G304
+			if err != nil {
+				t.Errorf("Expected '%s' to be copied - %v", name, err)
+				continue
+			}
+			if string(actual) != expected {
+				t.Errorf("Content of '%s' not as expected. Expected: %s, Actual: %s", name, expected, actual)
+			}
+		}
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		archive := &bytes.Buffer{}
+		if err := writeTar(archive, filepath.Join(srcDir, "a.txt"), "input.txt"); err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+
+		destFile := filepath.Join(t.TempDir(), "output.txt")
+		if err := extractTar(archive, "input.txt", destFile); err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+
+		actual, err := os.ReadFile(destFile) // #nosec G304
+		if err != nil {
+			t.Fatalf("Expected file to be copied - %v", err)
+		}
+		if string(actual) != "hello" {
+			t.Errorf("Content not as expected. Actual: %s", actual)
+		}
+	})
+}
+
+func TestExtractTar_PathTraversal(t *testing.T) {
+	archive := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(archive)
+	content := []byte("malicious")
+	if err := tarWriter.WriteHeader(&tar.Header{Name: "results/../../evil.txt", Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tarWriter.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	destDir := filepath.Join(t.TempDir(), "dest")
+	if err := extractTar(archive, "results", destDir); err == nil {
+		t.Errorf("Expected an error to be returned")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(destDir), "evil.txt")); !os.IsNotExist(err) {
+		t.Errorf("Not expecting a file to be written outside of the destination")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	exitErr := &ExitError{Pod: "example", Namespace: "default", Container: "app", Command: []string{"false"}, ExitCode: 3}
+
+	code, ok := ExitCode(fmt.Errorf("failed to exec command in pod - %w", exitErr))
+	if !ok || code != 3 {
+		t.Errorf("Expected exit code 3 to be found. Actual: %d, %t", code, ok)
+	}
+
+	if _, ok := ExitCode(errors.New("connection refused")); ok {
+		t.Errorf("Not expecting an exit code to be found")
+	}
+}
+
+func TestCopyPod(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	// Testing against Kind cluster
+	c, err := New(kindKubeconfig)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "clustertest-copy", Namespace: "default"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "busybox",
+				Image:   "busybox:1.37",
+				Command: []string{"sleep", "3600"},
+			}},
+		},
+	}
+	if err := c.Create(ctx, pod); err != nil {
+		t.Fatalf("Failed to create pod - %v", err)
+	}
+	defer func() { _ = c.Delete(context.Background(), pod) }()
+	for pod.Status.Phase != corev1.PodRunning {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("Pod didn't start in time")
+		case <-time.After(2 * time.Second):
+		}
+		if err := c.Get(ctx, cr.ObjectKeyFromObject(pod), pod); err != nil {
+			t.Fatalf("Failed to get pod - %v", err)
+		}
+	}
+	container := pod.Spec.Containers[0].Name
+
+	srcFile := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(srcFile, []byte("hello from the test"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.CopyToPod(ctx, pod.Name, pod.Namespace, container, srcFile, "/tmp/clustertest-input.txt"); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	stdout := &bytes.Buffer{}
+	err = c.Exec(ctx, pod.Name, pod.Namespace, container, []string{"cat", "/tmp/clustertest-input.txt"}, ExecOptions{Stdout: stdout})
+	if err != nil || stdout.String() != "hello from the test" {
+		t.Errorf("Expected file to be copied to pod. Actual: %s - %v", stdout, err)
+	}
+
+	err = c.Exec(ctx, pod.Name, pod.Namespace, container, []string{"sh", "-c", "cat > /tmp/clustertest-stdin.txt"}, ExecOptions{Stdin: strings.NewReader("from stdin")})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	destFile := filepath.Join(t.TempDir(), "output.txt")
+	if err := c.CopyFromPod(ctx, pod.Name, pod.Namespace, container, "/tmp/clustertest-stdin.txt", destFile); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if content, _ := os.ReadFile(destFile); string(content) != "from stdin" { // #nosec G304
+		t.Errorf("Expected file to be copied from pod. Actual: %s", content)
+	}
+
+	err = c.Exec(ctx, pod.Name, pod.Namespace, container, []string{"sh", "-c", "exit 3"}, ExecOptions{})
+	if code, ok := ExitCode(err); !ok || code != 3 {
+		t.Errorf("Expected exit code 3. Actual: %v", err)
+	}
+}
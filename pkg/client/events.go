@@ -2,9 +2,13 @@ package client
 
 import (
 	"context"
+	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 	cr "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -43,3 +47,14 @@ func (c *Client) GetNormalEventsForResource(ctx context.Context, resource cr.Obj
 func (c *Client) GetWarningEventsForResource(ctx context.Context, resource cr.Object) (*corev1.EventList, error) {
 	return c.GetEventsForResource(ctx, resource, fields.OneTermEqualSelector("type", corev1.EventTypeWarning))
 }
+
+// WatchEvents starts a watch on the Events in the provided namespace, or all namespaces if empty. The returned watch
+// must be stopped by the caller.
+func (c *Client) WatchEvents(ctx context.Context, namespace string, opts metav1.ListOptions) (watch.Interface, error) {
+	coreClient, err := kubernetes.NewForConfig(c.restConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed initializing kubernetes core client - %v", err)
+	}
+
+	return coreClient.CoreV1().Events(namespace).Watch(ctx, opts)
+}
@@ -3,25 +3,93 @@ package client
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 	"k8s.io/kubectl/pkg/scheme"
 
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
 )
 
+// ExecOptions are the options available when running a command in a container with `Exec`
+type ExecOptions struct {
+	// Stdin, if provided, is streamed to the command's stdin
+	Stdin io.Reader
+	// Stdout, if provided, receives the command's stdout as it is produced
+	Stdout io.Writer
+	// Stderr, if provided, receives the command's stderr as it is produced. Ignored if TTY is set as stderr is then
+	// combined with stdout.
+	Stderr io.Writer
+	// TTY allocates a terminal for the command
+	TTY bool
+}
+
+// ExitError is returned when a command run in a container completes with a non-zero exit code
+type ExitError struct {
+	Pod       string
+	Namespace string
+	Container string
+	Command   []string
+	ExitCode  int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command %v in container '%s' of pod '%s/%s' exited with code %d", e.Command, e.Container, e.Namespace, e.Pod, e.ExitCode)
+}
+
+// ExitCode returns the exit code of the command if the error was caused by a command exiting with a non-zero exit code
+// when run with `Exec` or `ExecInPod`
+func ExitCode(err error) (int, bool) {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode, true
+	}
+	return 0, false
+}
+
 // ExecInPod runs a given command within a pod running within the cluster and returns back the stdout and stderr
+//
+// If the command exits with a non-zero exit code the returned error wraps an `ExitError`, see `ExitCode`.
 func (c *Client) ExecInPod(ctx context.Context, podName, namespace, containerName string, command []string) (string, string, error) {
-	logger.Log("Running %v in container '%s' in pod '%s'", command, containerName, podName)
+	var stdout, stderr bytes.Buffer
+	err := c.Exec(ctx, podName, namespace, containerName, command, ExecOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return stdout.String(), stderr.String(), fmt.Errorf("failed to exec command in pod - %w", err)
+	}
 
-	tty := false
+	return stdout.String(), stderr.String(), nil
+}
+
+// Exec runs a given command within a container of a pod, streaming the provided stdin to the command and its output
+// to the provided writers as it is produced. It returns once the command completes or the context is cancelled.
+//
+// If the command exits with a non-zero exit code an `ExitError` is returned.
+//
+// Example:
+//
+//	err := client.Exec(ctx, "my-pod", "default", "app", []string{"sh", "-c", "cat > /tmp/input"}, client.ExecOptions{
+//		Stdin:  strings.NewReader("hello"),
+//		Stdout: os.Stdout,
+//		Stderr: os.Stderr,
+//	})
+//	if code, ok := client.ExitCode(err); ok {
+//		// handle non-zero exit code
+//	}
+func (c *Client) Exec(ctx context.Context, podName, namespace, containerName string, command []string, opts ExecOptions) error {
+	logger.Log("Running %v in container '%s' in pod '%s'", command, containerName, podName)
 
-	coreClient, err := kubernetes.NewForConfig(c.config)
+	coreClient, err := kubernetes.NewForConfig(c.restConfig())
 	if err != nil {
-		return "", "", fmt.Errorf("failed initializing kubernetes core client - %v", err)
+		return fmt.Errorf("failed initializing kubernetes core client - %v", err)
 	}
 
 	req := coreClient.CoreV1().RESTClient().Post().
@@ -33,27 +101,54 @@ func (c *Client) ExecInPod(ctx context.Context, podName, namespace, containerNam
 	req.VersionedParams(&corev1.PodExecOptions{
 		Container: containerName,
 		Command:   command,
-		Stdin:     false,
-		Stdout:    true,
-		Stderr:    true,
-		TTY:       tty,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    opts.Stdout != nil,
+		Stderr:    opts.Stderr != nil && !opts.TTY,
+		TTY:       opts.TTY,
 	}, scheme.ParameterCodec)
 
-	var stdout, stderr bytes.Buffer
-	exec, err := remotecommand.NewSPDYExecutor(c.config, "POST", req.URL())
+	exec, err := remotecommand.NewSPDYExecutor(c.restConfig(), "POST", req.URL())
 	if err != nil {
-		return "", "", fmt.Errorf("failed to exec command in pod - %v", err)
+		return fmt.Errorf("failed to exec command in pod - %v", err)
 	}
 
-	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  nil,
-		Stdout: &stdout,
-		Stderr: &stderr,
-		Tty:    tty,
-	})
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if !opts.TTY {
+		streamOptions.Stderr = opts.Stderr
+	}
+
+	err = exec.StreamWithContext(ctx, streamOptions)
 	if err != nil {
-		return stdout.String(), stderr.String(), fmt.Errorf("failed to exec command in pod - %v", err)
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			return &ExitError{
+				Pod:       podName,
+				Namespace: namespace,
+				Container: containerName,
+				Command:   command,
+				ExitCode:  exitErr.ExitStatus(),
+			}
+		}
+		return err
 	}
 
-	return stdout.String(), stderr.String(), err
+	return nil
+}
+
+// execWithStderr runs the command with `Exec`, including any stderr output in the returned error
+func (c *Client) execWithStderr(ctx context.Context, podName, namespace, containerName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	var stderr bytes.Buffer
+	err := c.Exec(ctx, podName, namespace, containerName, command, ExecOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return err
 }
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CreateHook is a function that is called with every object successfully created through a Client
+type CreateHook func(c *Client, obj cr.Object)
+
+// hookedClient wraps a controller-runtime client and notifies the owning Client of all successful creations
+type hookedClient struct {
+	cr.Client
+
+	owner *Client
+}
+
+// Create creates the given object and, if successful, calls all registered CreateHooks
+func (h *hookedClient) Create(ctx context.Context, obj cr.Object, opts ...cr.CreateOption) error {
+	err := h.Client.Create(ctx, obj, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range h.owner.getCreateHooks() {
+		hook(h.owner, obj)
+	}
+
+	return nil
+}
+
+// createHooks holds the registered CreateHooks of a Client
+type createHooks struct {
+	mu    sync.RWMutex
+	hooks []CreateHook
+}
+
+// AddCreateHook registers a function that will be called each time an object is successfully created through this
+// Client, including those created via CreateOrUpdate, DeployApp, DeployAppManifests and ApplyManifests.
+//
+// Clients created with the constructors of this package support hooks being added at any time.
+// Clients constructed directly (e.g. wrapping a fake client in tests) are only wrapped when the first hook is added,
+// so hooks must be added before such a Client is used.
+func (c *Client) AddCreateHook(hook CreateHook) {
+	c.createHooks.mu.Lock()
+	defer c.createHooks.mu.Unlock()
+
+	if _, ok := c.Client.(*hookedClient); !ok {
+		c.Client = &hookedClient{Client: c.Client, owner: c}
+	}
+
+	c.createHooks.hooks = append(c.createHooks.hooks, hook)
+}
+
+func (c *Client) getCreateHooks() []CreateHook {
+	c.createHooks.mu.RLock()
+	defer c.createHooks.mu.RUnlock()
+
+	return append([]CreateHook{}, c.createHooks.hooks...)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAddCreateHook(t *testing.T) {
+	c := &Client{Client: fake.NewClientBuilder().Build()}
+
+	created := []string{}
+	c.AddCreateHook(func(hookClient *Client, obj cr.Object) {
+		if hookClient != c {
+			t.Errorf("Expected hook to be called with the owning client")
+		}
+		created = append(created, obj.GetName())
+	})
+
+	newConfigMap := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+		}
+	}
+
+	if err := c.Create(context.Background(), newConfigMap()); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	// Second create fails with AlreadyExists and must not trigger the hook
+	if err := c.Create(context.Background(), newConfigMap()); err == nil {
+		t.Errorf("Was expecting an error when creating an existing resource")
+	}
+	// Updating via CreateOrUpdate must not trigger the hook
+	if err := c.CreateOrUpdate(context.Background(), newConfigMap()); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if len(created) != 1 || created[0] != "test" {
+		t.Errorf("Unexpected hook calls. Expected: [test], Actual: %v", created)
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// KubeconfigProvider provides the kubeconfig used to access a workload cluster
+type KubeconfigProvider interface {
+	// Name returns a human-friendly name of the provider, used in logs and errors
+	Name() string
+	// GetKubeconfig returns the raw kubeconfig for the given workload cluster. The provided Client is that of the
+	// Management Cluster the workload cluster belongs to.
+	GetKubeconfig(ctx context.Context, mcClient *Client, clusterName string, clusterNamespace string) (string, error)
+}
+
+// DefaultKubeconfigProviders returns the providers used when none have been configured on a Client: Teleport first,
+// falling back to CAPI.
+func DefaultKubeconfigProviders() []KubeconfigProvider {
+	return []KubeconfigProvider{
+		&TeleportKubeconfigProvider{},
+		&CAPIKubeconfigProvider{},
+	}
+}
+
+// TeleportKubeconfigProvider provides the kubeconfig from the `teleport-<cluster>-kubeconfig` Secret created by
+// Teleport tbot on the MC
+type TeleportKubeconfigProvider struct {
+	// Namespace is where the Teleport kubeconfig Secrets are found. Defaults to `giantswarm`.
+	Namespace string
+}
+
+// Name returns the name of the provider
+func (p *TeleportKubeconfigProvider) Name() string {
+	return "Teleport"
+}
+
+// GetKubeconfig returns the Teleport kubeconfig for the cluster
+func (p *TeleportKubeconfigProvider) GetKubeconfig(ctx context.Context, mcClient *Client, clusterName string, _ string) (string, error) {
+	namespace := p.Namespace
+	if namespace == "" {
+		namespace = "giantswarm"
+	}
+	return mcClient.getTeleportKubeConfig(ctx, clusterName, namespace)
+}
+
+// CAPIKubeconfigProvider provides the kubeconfig from the `<cluster>-kubeconfig` Secret created by CAPI controllers on
+// the MC. The server hostname is rewritten to use the cluster's DNS name if it is found to be using an IP address or
+// an AWS ELB hostname.
+type CAPIKubeconfigProvider struct{}
+
+// Name returns the name of the provider
+func (p *CAPIKubeconfigProvider) Name() string {
+	return "CAPI"
+}
+
+// GetKubeconfig returns the CAPI kubeconfig for the cluster
+func (p *CAPIKubeconfigProvider) GetKubeconfig(ctx context.Context, mcClient *Client, clusterName string, clusterNamespace string) (string, error) {
+	return mcClient.getCAPIKubeConfig(ctx, clusterName, clusterNamespace)
+}
+
+// StaticKubeconfigProvider provides kubeconfigs from local files
+type StaticKubeconfigProvider struct {
+	// Files maps a workload cluster name to the path of its kubeconfig file
+	Files map[string]string
+}
+
+// Name returns the name of the provider
+func (p *StaticKubeconfigProvider) Name() string {
+	return "static file"
+}
+
+// GetKubeconfig returns the contents of the kubeconfig file configured for the cluster
+func (p *StaticKubeconfigProvider) GetKubeconfig(_ context.Context, _ *Client, clusterName string, _ string) (string, error) {
+	path, ok := p.Files[clusterName]
+	if !ok {
+		return "", fmt.Errorf("no kubeconfig file configured for cluster %s", clusterName)
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", fmt.Errorf("failed to read kubeconfig file '%s': %w", path, err)
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("kubeconfig file '%s' is empty", path)
+	}
+
+	return string(data), nil
+}
+
+// KubeconfigProviderFunc allows a plain function to be used as a custom KubeconfigProvider
+type KubeconfigProviderFunc func(ctx context.Context, mcClient *Client, clusterName string, clusterNamespace string) (string, error)
+
+// Name returns the name of the provider
+func (f KubeconfigProviderFunc) Name() string {
+	return "custom"
+}
+
+// GetKubeconfig calls the underlying function
+func (f KubeconfigProviderFunc) GetKubeconfig(ctx context.Context, mcClient *Client, clusterName string, clusterNamespace string) (string, error) {
+	return f(ctx, mcClient, clusterName, clusterNamespace)
+}
+
+// kubeconfigProviders holds the configured KubeconfigProviders of a Client
+type kubeconfigProviders struct {
+	mu        sync.RWMutex
+	providers []KubeconfigProvider
+}
+
+// SetKubeconfigProviders configures the providers, in order of preference, used by `GetClusterKubeConfig` to get the
+// kubeconfig of a workload cluster managed by this (Management Cluster) Client. This also affects everything that
+// relies on it, such as `NewFromSecret` and `wait.IsClusterReadyCondition`.
+//
+// Providing no providers resets back to the `DefaultKubeconfigProviders`.
+//
+// Example:
+//
+//	mcClient.SetKubeconfigProviders(
+//		&client.StaticKubeconfigProvider{Files: map[string]string{"my-cluster": "/tmp/my-cluster.yaml"}},
+//		&client.CAPIKubeconfigProvider{},
+//	)
+func (c *Client) SetKubeconfigProviders(providers ...KubeconfigProvider) {
+	c.kubeconfigProviders.mu.Lock()
+	defer c.kubeconfigProviders.mu.Unlock()
+	c.kubeconfigProviders.providers = providers
+}
+
+// GetKubeconfigProviders returns the providers, in order of preference, used by `GetClusterKubeConfig`
+func (c *Client) GetKubeconfigProviders() []KubeconfigProvider {
+	c.kubeconfigProviders.mu.RLock()
+	defer c.kubeconfigProviders.mu.RUnlock()
+
+	if len(c.kubeconfigProviders.providers) == 0 {
+		return DefaultKubeconfigProviders()
+	}
+	return append([]KubeconfigProvider{}, c.kubeconfigProviders.providers...)
+}
+
+// GetClusterKubeConfig retrieves the kubeconfig of the provided workload cluster using each of the configured
+// KubeconfigProviders in order, returning the first successfully found. By default this is the Teleport kubeconfig
+// with a fallback to the CAPI kubeconfig (see `SetKubeconfigProviders`).
+func (c *Client) GetClusterKubeConfig(ctx context.Context, clusterName string, clusterNamespace string) (string, error) {
+	errs := []error{}
+	for _, provider := range c.GetKubeconfigProviders() {
+		kubeconfig, err := provider.GetKubeconfig(ctx, c, clusterName, clusterNamespace)
+		if err == nil {
+			return kubeconfig, nil
+		}
+
+		logger.Log("Failed to get %s kubeconfig: %v", provider.Name(), err)
+		errs = append(errs, fmt.Errorf("failed to get %s kubeconfig: %w", provider.Name(), err))
+	}
+
+	return "", utilerrors.NewAggregate(errs)
+}
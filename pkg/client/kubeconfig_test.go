@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetClusterKubeConfig(t *testing.T) {
+	kubeconfigPath := path.Clean("./test_data/mock_kubeconfig.yaml")
+	kubeconfig, err := os.ReadFile(kubeconfigPath)
+	if err != nil {
+		t.Fatalf("Failed to read mock kubeconfig - %v", err)
+	}
+
+	teleportSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "teleport-example-kubeconfig", Namespace: "giantswarm"},
+		Data:       map[string][]byte{"kubeconfig.yaml": kubeconfig},
+	}
+	capiSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-kubeconfig", Namespace: "org-test"},
+		Data:       map[string][]byte{"value": kubeconfig},
+	}
+	clusterValues := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-cluster-values", Namespace: "org-test"},
+		Data:       map[string]string{"values": "baseDomain: example.gigantic.io\n"},
+	}
+
+	testCases := []struct {
+		name             string
+		objects          []*corev1.Secret
+		providers        []KubeconfigProvider
+		expectError      bool
+		expectedContains string
+	}{
+		{
+			name:             "default prefers teleport",
+			objects:          []*corev1.Secret{teleportSecret, capiSecret},
+			expectedContains: "https://127.0.0.1:64329",
+		},
+		{
+			name:             "default falls back to CAPI with hostname rewrite",
+			objects:          []*corev1.Secret{capiSecret},
+			expectedContains: "https://api.example.gigantic.io:64329",
+		},
+		{
+			name:        "no kubeconfig found",
+			objects:     []*corev1.Secret{},
+			expectError: true,
+		},
+		{
+			name:             "static file",
+			objects:          []*corev1.Secret{},
+			providers:        []KubeconfigProvider{&StaticKubeconfigProvider{Files: map[string]string{"example": kubeconfigPath}}},
+			expectedContains: "kind-example-cluster",
+		},
+		{
+			name:    "custom provider used in order",
+			objects: []*corev1.Secret{teleportSecret},
+			providers: []KubeconfigProvider{
+				KubeconfigProviderFunc(func(_ context.Context, _ *Client, clusterName string, clusterNamespace string) (string, error) {
+					return "custom-" + clusterName + "-" + clusterNamespace, nil
+				}),
+				&TeleportKubeconfigProvider{},
+			},
+			expectedContains: "custom-example-org-test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithObjects(clusterValues.DeepCopy())
+			for _, obj := range tc.objects {
+				builder = builder.WithObjects(obj.DeepCopy())
+			}
+			c := &Client{Client: builder.Build()}
+			c.SetKubeconfigProviders(tc.providers...)
+
+			actual, err := c.GetClusterKubeConfig(context.Background(), "example", "org-test")
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Was expecting an error to be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if !strings.Contains(actual, tc.expectedContains) {
+				t.Errorf("Kubeconfig not as expected. Expected to contain: %s, Actual:\n%s", tc.expectedContains, actual)
+			}
+		})
+	}
+}
@@ -1,60 +1,260 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
 )
 
+// LogOptions are the options available when fetching logs with `GetContainerLogs` or `FollowLogs`
+type LogOptions struct {
+	// Containers are the names of the containers to get logs from. Defaults to all containers (including
+	// initContainers and ephemeralContainers) or, if `Previous` is set, all containers that have been restarted.
+	Containers []string
+	// Previous gets the logs of the previous instance of each container, e.g. one in CrashLoopBackOff
+	Previous bool
+	// TailLines, if set, only returns that many lines from the end of the logs of each container
+	TailLines int64
+	// SinceTime, if set, only returns logs written at or after the given time
+	SinceTime time.Time
+	// SinceSeconds, if set, only returns logs written within the given number of seconds. Ignored if SinceTime is set.
+	SinceSeconds int64
+	// LimitBytes, if set, limits the logs returned for each container to the given number of bytes
+	LimitBytes int64
+	// Timestamps prefixes each log line with the time it was written
+	Timestamps bool
+}
+
+// ContainerLogs are the logs of a single container of a Pod
+type ContainerLogs struct {
+	Container string
+	Logs      string
+	// Previous indicates these are the logs of the previous instance of the container
+	Previous bool
+	// Err is set if the logs of the container couldn't be fetched
+	Err error
+}
+
+// podLogOptions returns the PodLogOptions for fetching the logs of the given container
+func (o LogOptions) podLogOptions(containerName string, follow bool) *corev1.PodLogOptions {
+	podLogOptions := &corev1.PodLogOptions{
+		Container:  containerName,
+		Follow:     follow,
+		Previous:   o.Previous,
+		Timestamps: o.Timestamps,
+	}
+	if o.TailLines > 0 {
+		podLogOptions.TailLines = &o.TailLines
+	}
+	if !o.SinceTime.IsZero() {
+		podLogOptions.SinceTime = &v1.Time{Time: o.SinceTime}
+	} else if o.SinceSeconds > 0 {
+		podLogOptions.SinceSeconds = &o.SinceSeconds
+	}
+	if o.LimitBytes > 0 {
+		podLogOptions.LimitBytes = &o.LimitBytes
+	}
+	return podLogOptions
+}
+
 // GetLogs fetches the logs from the provided Pod. If `numOfLines` is provided (instead of `nil`) then that
 // many lines will be returned from the end of the logs.
 // If multiple containers (including initContainers and ephermeralContainers) are found in the pod then
 // logs from all of them will be collected.
+//
+// See `GetContainerLogs` to get the logs of each container separately.
 func (c *Client) GetLogs(ctx context.Context, pod *corev1.Pod, numOfLines *int64) (string, error) {
-	coreClient, err := kubernetes.NewForConfig(c.config)
-	if err != nil {
-		return "", fmt.Errorf("failed initializing kubernetes core client - %v", err)
+	return c.getCombinedLogs(ctx, pod, numOfLines, false)
+}
+
+// GetPreviousLogs fetches the logs of the previous instance of each container in the provided Pod that has been
+// restarted, such as those in CrashLoopBackOff. If `numOfLines` is provided (instead of `nil`) then that
+// many lines will be returned from the end of the logs of each container.
+// If no containers have been restarted an empty string is returned.
+func (c *Client) GetPreviousLogs(ctx context.Context, pod *corev1.Pod, numOfLines *int64) (string, error) {
+	return c.getCombinedLogs(ctx, pod, numOfLines, true)
+}
+
+func (c *Client) getCombinedLogs(ctx context.Context, pod *corev1.Pod, numOfLines *int64, previous bool) (string, error) {
+	opts := LogOptions{Previous: previous}
+	if numOfLines != nil {
+		opts.TailLines = *numOfLines
 	}
 
-	pod, err = coreClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, v1.GetOptions{})
+	containerLogs, err := c.GetContainerLogs(ctx, pod, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get pod - %v", err)
+		return "", err
 	}
 
 	buf := new(bytes.Buffer)
+	for _, logs := range containerLogs {
+		if logs.Err != nil {
+			logger.Log("Error getting logs of container '%s' - %v", logs.Container, logs.Err)
+			continue
+		}
+		buf.WriteString(logs.Logs)
+	}
 
-	allContainers := []string{}
-	allContainers = append(allContainers, getContainerNames(pod.Spec.InitContainers)...)
-	allContainers = append(allContainers, getContainerNames(pod.Spec.Containers)...)
-	allContainers = append(allContainers, getEphemeralContainerNames(pod.Spec.EphemeralContainers)...)
+	return buf.String(), nil
+}
 
-	for _, containerName := range allContainers {
-		req := coreClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
-			TailLines: numOfLines,
-			Container: containerName,
-		})
-		podLogs, err := req.Stream(ctx)
+// GetContainerLogs fetches the logs of each container of the provided Pod separately, in the order the containers
+// are defined in the Pod. Failing to get the logs of a container doesn't stop the logs of other containers from being
+// fetched, instead the error is set on the container's `ContainerLogs`.
+//
+// Example:
+//
+//	containerLogs, err := client.GetContainerLogs(ctx, pod, client.LogOptions{Previous: true, TailLines: 50})
+//	for _, logs := range containerLogs {
+//		logger.Log("Previous logs of container '%s': %s", logs.Container, logs.Logs)
+//	}
+func (c *Client) GetContainerLogs(ctx context.Context, pod *corev1.Pod, opts LogOptions) ([]ContainerLogs, error) {
+	coreClient, pod, err := c.getPodForLogs(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	containerLogs := []ContainerLogs{}
+	for _, containerName := range getLogContainerNames(pod, opts) {
+		logs := ContainerLogs{Container: containerName, Previous: opts.Previous}
+
+		podLogs, err := coreClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts.podLogOptions(containerName, false)).Stream(ctx)
 		if err != nil {
-			logger.Log("Error in opening log stream of container '%s' - %v", containerName, err)
+			logs.Err = fmt.Errorf("failed opening log stream of container '%s' - %v", containerName, err)
+			containerLogs = append(containerLogs, logs)
 			continue
 		}
-		defer podLogs.Close()
 
+		buf := new(bytes.Buffer)
 		_, err = io.Copy(buf, podLogs)
+		_ = podLogs.Close()
 		if err != nil {
-			logger.Log("Error in copying from podLogs to buffer for container '%s' - %v", containerName, err)
-			continue
+			logs.Err = fmt.Errorf("failed reading logs of container '%s' - %v", containerName, err)
 		}
+		logs.Logs = buf.String()
+		containerLogs = append(containerLogs, logs)
 	}
 
-	return buf.String(), nil
+	return containerLogs, nil
+}
+
+// FollowLogs streams the logs of the provided Pod's containers to the given writer as they are written until the
+// context is cancelled or all the containers have stopped. When following more than one container each line is
+// prefixed with the container name (e.g. `[app] `).
+//
+// Example:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	go client.FollowLogs(ctx, pod, os.Stdout, client.LogOptions{Containers: []string{"app"}, SinceSeconds: 60})
+func (c *Client) FollowLogs(ctx context.Context, pod *corev1.Pod, w io.Writer, opts LogOptions) error {
+	coreClient, pod, err := c.getPodForLogs(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	containerNames := getLogContainerNames(pod, opts)
+	if len(containerNames) == 0 {
+		return fmt.Errorf("no containers found to follow logs of in pod '%s/%s'", pod.Namespace, pod.Name)
+	}
+
+	var writeMu sync.Mutex
+	errs := make(chan error, len(containerNames))
+	for _, containerName := range containerNames {
+		go func(containerName string) {
+			prefix := ""
+			if len(containerNames) > 1 {
+				prefix = fmt.Sprintf("[%s] ", containerName)
+			}
+
+			podLogs, err := coreClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts.podLogOptions(containerName, true)).Stream(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					err = nil
+				} else {
+					err = fmt.Errorf("failed opening log stream of container '%s' - %v", containerName, err)
+				}
+				errs <- err
+				return
+			}
+			defer podLogs.Close()
+
+			reader := bufio.NewReader(podLogs)
+			for {
+				line, err := reader.ReadBytes('\n')
+				if len(line) > 0 {
+					writeMu.Lock()
+					_, writeErr := fmt.Fprintf(w, "%s%s", prefix, line)
+					writeMu.Unlock()
+					if writeErr != nil {
+						errs <- writeErr
+						return
+					}
+				}
+				if err != nil {
+					if errors.Is(err, io.EOF) || ctx.Err() != nil {
+						err = nil
+					}
+					errs <- err
+					return
+				}
+			}
+		}(containerName)
+	}
+
+	var followErrs []error
+	for range containerNames {
+		if err := <-errs; err != nil {
+			followErrs = append(followErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(followErrs)
+}
+
+// getPodForLogs returns a kubernetes core client along with the latest version of the provided Pod
+func (c *Client) getPodForLogs(ctx context.Context, pod *corev1.Pod) (*kubernetes.Clientset, *corev1.Pod, error) {
+	coreClient, err := kubernetes.NewForConfig(c.restConfig())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed initializing kubernetes core client - %v", err)
+	}
+
+	pod, err = coreClient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, v1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pod - %v", err)
+	}
+
+	return coreClient, pod, nil
+}
+
+// getLogContainerNames returns the names of the containers in the Pod to get logs from
+func getLogContainerNames(pod *corev1.Pod, opts LogOptions) []string {
+	if len(opts.Containers) > 0 {
+		return opts.Containers
+	}
+
+	if opts.Previous {
+		// Only restarted containers have logs from a previous instance
+		return getRestartedContainerNames(pod)
+	}
+
+	allContainers := []string{}
+	allContainers = append(allContainers, getContainerNames(pod.Spec.InitContainers)...)
+	allContainers = append(allContainers, getContainerNames(pod.Spec.Containers)...)
+	allContainers = append(allContainers, getEphemeralContainerNames(pod.Spec.EphemeralContainers)...)
+	return allContainers
 }
 
 func getContainerNames(containers []corev1.Container) []string {
@@ -72,3 +272,16 @@ func getEphemeralContainerNames(containers []corev1.EphemeralContainer) []string
 	}
 	return names
 }
+
+// getRestartedContainerNames returns the names of all containers in the Pod that have a previous instance
+func getRestartedContainerNames(pod *corev1.Pod) []string {
+	names := []string{}
+	statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.RestartCount > 0 {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
@@ -1,14 +1,159 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// serveFakeLogs echoes back the requested container and options as the logs. When following, a line is written every
+// few milliseconds until the request is cancelled.
+func serveFakeLogs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	container := query.Get("container")
+	if container == "missing" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"container not found","reason":"BadRequest","code":400}`)
+		return
+	}
+
+	if query.Get("follow") != "true" {
+		query.Del("container")
+		fmt.Fprintf(w, "%s: %s\n", container, query.Encode())
+		return
+	}
+
+	flusher := w.(http.Flusher)
+	for i := 0; ; i++ {
+		fmt.Fprintf(w, "%s line %d\n", container, i)
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to write to while it's being read
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newLogsTestClient(t *testing.T) *Client {
+	server := newFakeAPIServer(t, "token")
+	c, err := newClient(server.restConfig("token"), "test", Options{})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	return c
+}
+
+var examplePod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+
+func TestGetContainerLogs(t *testing.T) {
+	c := newLogsTestClient(t)
+	sinceTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		expected []ContainerLogs
+	}{
+		{
+			name: "all containers",
+			opts: LogOptions{},
+			expected: []ContainerLogs{
+				{Container: "init", Logs: "init: \n"},
+				{Container: "app", Logs: "app: \n"},
+				{Container: "sidecar", Logs: "sidecar: \n"},
+			},
+		},
+		{
+			name: "previous only includes restarted containers",
+			opts: LogOptions{Previous: true, TailLines: 10},
+			expected: []ContainerLogs{
+				{Container: "app", Logs: "app: previous=true&tailLines=10\n", Previous: true},
+			},
+		},
+		{
+			name: "since time takes priority over since seconds",
+			opts: LogOptions{Containers: []string{"sidecar"}, SinceTime: sinceTime, SinceSeconds: 60, LimitBytes: 100, Timestamps: true},
+			expected: []ContainerLogs{
+				{Container: "sidecar", Logs: "sidecar: limitBytes=100&sinceTime=2024-01-02T03%3A04%3A05Z&timestamps=true\n"},
+			},
+		},
+		{
+			name: "since seconds",
+			opts: LogOptions{Containers: []string{"app"}, SinceSeconds: 60},
+			expected: []ContainerLogs{
+				{Container: "app", Logs: "app: sinceSeconds=60\n"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := c.GetContainerLogs(context.Background(), examplePod, tc.opts)
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if len(actual) != len(tc.expected) {
+				t.Fatalf("Expected %d container logs. Actual: %v", len(tc.expected), actual)
+			}
+			for i := range tc.expected {
+				if actual[i].Err != nil {
+					t.Errorf("Not expecting an error for container '%s' - %v", actual[i].Container, actual[i].Err)
+				}
+				actual[i].Err = nil
+				if actual[i] != tc.expected[i] {
+					t.Errorf("Container logs not as expected.\nExpected: %#v\nActual:   %#v", tc.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetContainerLogs_ContainerError(t *testing.T) {
+	c := newLogsTestClient(t)
+
+	actual, err := c.GetContainerLogs(context.Background(), examplePod, LogOptions{Containers: []string{"missing", "app"}})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(actual) != 2 {
+		t.Fatalf("Expected logs for both containers. Actual: %v", actual)
+	}
+	if actual[0].Err == nil {
+		t.Errorf("Expected an error for the missing container")
+	}
+	if actual[1].Err != nil || actual[1].Logs != "app: \n" {
+		t.Errorf("Expected logs of other containers to still be returned. Actual: %#v", actual[1])
+	}
+}
+
 func TestGetLogs(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -54,3 +199,69 @@ func TestGetLogs(t *testing.T) {
 		t.Errorf("Unexpected number of lines returned - Expected=%d, Actual=%d", numOfLines, actualLines)
 	}
 }
+
+func TestGetLogs_FakeServer(t *testing.T) {
+	c := newLogsTestClient(t)
+	numOfLines := int64(5)
+
+	actual, err := c.GetLogs(context.Background(), examplePod, &numOfLines)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	expected := "init: tailLines=5\napp: tailLines=5\nsidecar: tailLines=5\n"
+	if actual != expected {
+		t.Errorf("Logs not as expected.\nExpected: %q\nActual:   %q", expected, actual)
+	}
+
+	actual, err = c.GetPreviousLogs(context.Background(), examplePod, nil)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if expected := "app: previous=true\n"; actual != expected {
+		t.Errorf("Previous logs not as expected.\nExpected: %q\nActual:   %q", expected, actual)
+	}
+}
+
+func TestFollowLogs(t *testing.T) {
+	c := newLogsTestClient(t)
+
+	t.Run("multiple containers are prefixed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		out := &syncBuffer{}
+		if err := c.FollowLogs(ctx, examplePod, out, LogOptions{Containers: []string{"app", "sidecar"}}); err != nil {
+			t.Fatalf("Not expecting an error to be returned once the context is done - %v", err)
+		}
+
+		logs := out.String()
+		for _, expected := range []string{"[app] app line 0\n", "[sidecar] sidecar line 0\n", "[app] app line 1\n"} {
+			if !strings.Contains(logs, expected) {
+				t.Errorf("Expected followed logs to contain %q. Actual: %q", expected, logs)
+			}
+		}
+	})
+
+	t.Run("single container isn't prefixed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		out := &syncBuffer{}
+		if err := c.FollowLogs(ctx, examplePod, out, LogOptions{Containers: []string{"app"}}); err != nil {
+			t.Fatalf("Not expecting an error to be returned once the context is done - %v", err)
+		}
+		if logs := out.String(); !strings.HasPrefix(logs, "app line 0\n") {
+			t.Errorf("Expected followed logs not to be prefixed. Actual: %q", logs)
+		}
+	})
+
+	t.Run("failing container", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := c.FollowLogs(ctx, examplePod, &syncBuffer{}, LogOptions{Containers: []string{"missing"}})
+		if err == nil {
+			t.Errorf("Expected an error to be returned")
+		}
+	})
+}
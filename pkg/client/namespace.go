@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// ListNamespaceObjects returns the metadata of all objects, of any listable resource type known to the API server,
+// found in the provided namespace. Events are excluded.
+//
+// Resource types that fail to be listed (e.g. due to missing permissions) are logged and skipped.
+func (c *Client) ListNamespaceObjects(ctx context.Context, namespace string) ([]metav1.PartialObjectMetadata, error) {
+	if c.restConfig() == nil {
+		return nil, fmt.Errorf("client has no REST config available for discovery")
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(c.restConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client - %v", err)
+	}
+
+	resourceLists, err := discoveryClient.ServerPreferredNamespacedResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, fmt.Errorf("failed to discover namespaced resources - %v", err)
+	}
+
+	objects := []metav1.PartialObjectMetadata{}
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") || !hasVerb(resource.Verbs, "list") || resource.Kind == "Event" {
+				continue
+			}
+
+			list := &metav1.PartialObjectMetadataList{}
+			list.SetGroupVersionKind(gv.WithKind(resource.Kind + "List"))
+			err := c.List(ctx, list, cr.InNamespace(namespace))
+			if err != nil {
+				logger.Log("Failed to list %s in namespace '%s' - %v", resource.Name, namespace, err)
+				continue
+			}
+
+			for i := range list.Items {
+				item := list.Items[i]
+				item.SetGroupVersionKind(gv.WithKind(resource.Kind))
+				objects = append(objects, item)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func hasVerb(verbs metav1.Verbs, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
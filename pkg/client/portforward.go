@@ -0,0 +1,294 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/kubectl/pkg/util"
+	"k8s.io/kubectl/pkg/util/podutils"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// portForwardAddress is the local address ports are forwarded from
+const portForwardAddress = "127.0.0.1"
+
+// ForwardedPort is a local port forwarded to a port of a Pod
+type ForwardedPort struct {
+	Local  uint16
+	Remote uint16
+}
+
+// PortForward is an active port-forward to a Pod started with `PortForward`
+type PortForward struct {
+	// Pod is the Pod the ports are forwarded to
+	Pod types.NamespacedName
+	// Ports are the forwarded ports, with the local port that was chosen if a random one was requested
+	Ports []ForwardedPort
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// Addresses returns the local addresses (e.g. `127.0.0.1:54321`) of all forwarded ports, in the order they were
+// requested
+func (p *PortForward) Addresses() []string {
+	addresses := []string{}
+	for _, port := range p.Ports {
+		addresses = append(addresses, net.JoinHostPort(portForwardAddress, strconv.Itoa(int(port.Local))))
+	}
+	return addresses
+}
+
+// Address returns the local address that is forwarded to the given port of the Pod, or an empty string if that port
+// isn't forwarded
+func (p *PortForward) Address(remotePort uint16) string {
+	for _, port := range p.Ports {
+		if port.Remote == remotePort {
+			return net.JoinHostPort(portForwardAddress, strconv.Itoa(int(port.Local)))
+		}
+	}
+	return ""
+}
+
+// Stop stops forwarding all ports. It is safe to call multiple times.
+func (p *PortForward) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	<-p.done
+}
+
+// Done returns a channel that is closed once the port-forward has stopped, either due to `Stop`, the context being
+// cancelled or the connection to the Pod being lost
+func (p *PortForward) Done() <-chan struct{} {
+	return p.done
+}
+
+// Err returns the error that caused the port-forward to stop, if any. It should only be called once `Done` is closed.
+func (p *PortForward) Err() error {
+	return p.err
+}
+
+// PortForward forwards local ports to the provided Pod or Service, in the same way as `kubectl port-forward`, so that
+// services within the cluster can be reached without being exposed publicly. The port-forward is stopped when the
+// provided context is cancelled or `Stop` is called.
+//
+// Ports are specified as `[LOCAL_PORT:]REMOTE_PORT` where a local port of `0` (or omitting it, e.g. `:9090`) selects
+// a random available local port. The remote port can be either a number or the name of a port of the Pod or Service.
+// For a Service, the remote port is the Service's port and the ports are forwarded to a running Pod selected by it.
+//
+// Example:
+//
+//	pf, err := client.PortForward(ctx, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "prometheus", Namespace: "monitoring"}}, ":9090")
+//	if err != nil {
+//		return err
+//	}
+//	defer pf.Stop()
+//
+//	resp, err := http.Get(fmt.Sprintf("http://%s/-/ready", pf.Addresses()[0]))
+func (c *Client) PortForward(ctx context.Context, target cr.Object, ports ...string) (*PortForward, error) {
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("at least one port must be provided")
+	}
+
+	pod, podPorts, err := c.getPortForwardPod(ctx, target, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log("Forwarding ports %v to pod '%s/%s'", podPorts, pod.Namespace, pod.Name)
+
+	restConfig := c.restConfig()
+	coreClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed initializing kubernetes core client - %v", err)
+	}
+	req := coreClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward transport - %v", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+	tunnelingDialer, err := portforward.NewSPDYOverWebsocketDialer(req.URL(), restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward dialer - %v", err)
+	}
+	// Prefer websockets, falling back to SPDY for older API servers or proxies that don't support them
+	dialer = portforward.NewFallbackDialer(tunnelingDialer, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+
+	pf := &PortForward{
+		Pod:    types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace},
+		stopCh: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	readyCh := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{portForwardAddress}, podPorts, pf.stopCh, readyCh, logWriter{}, logWriter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port-forward - %v", err)
+	}
+
+	go func() {
+		defer close(pf.done)
+		pf.err = forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case <-pf.done:
+		return nil, fmt.Errorf("failed to forward ports to pod '%s/%s' - %v", pod.Namespace, pod.Name, pf.err)
+	case <-ctx.Done():
+		pf.Stop()
+		return nil, ctx.Err()
+	}
+
+	forwardedPorts, err := forwarder.GetPorts()
+	if err != nil {
+		pf.Stop()
+		return nil, fmt.Errorf("failed to get forwarded ports - %v", err)
+	}
+	for _, port := range forwardedPorts {
+		pf.Ports = append(pf.Ports, ForwardedPort{Local: port.Local, Remote: port.Remote})
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			pf.Stop()
+		case <-pf.done:
+		}
+	}()
+
+	return pf, nil
+}
+
+// getPortForwardPod returns the Pod to forward ports to for the provided Pod or Service along with the ports
+// translated to numeric ports of the Pod
+func (c *Client) getPortForwardPod(ctx context.Context, target cr.Object, ports []string) (*corev1.Pod, []string, error) {
+	key := cr.ObjectKeyFromObject(target)
+
+	switch target.(type) {
+	case *corev1.Pod:
+		pod := &corev1.Pod{}
+		if err := c.Get(ctx, key, pod); err != nil {
+			return nil, nil, fmt.Errorf("failed to get pod '%s' - %v", key, err)
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			return nil, nil, fmt.Errorf("unable to forward ports to pod '%s' as it isn't running, current phase: %s", key, pod.Status.Phase)
+		}
+
+		podPorts, err := translatePorts(ports, func(remotePort string) (int32, error) {
+			return util.LookupContainerPortNumberByName(*pod, remotePort)
+		}, nil)
+		return pod, podPorts, err
+
+	case *corev1.Service:
+		svc := &corev1.Service{}
+		if err := c.Get(ctx, key, svc); err != nil {
+			return nil, nil, fmt.Errorf("failed to get service '%s' - %v", key, err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, nil, fmt.Errorf("unable to forward ports to service '%s' as it has no pod selector", key)
+		}
+
+		podList := &corev1.PodList{}
+		err := c.List(ctx, podList, cr.InNamespace(svc.Namespace), cr.MatchingLabelsSelector{Selector: labels.SelectorFromSet(svc.Spec.Selector)})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list pods of service '%s' - %v", key, err)
+		}
+		var pod *corev1.Pod
+		for i := range podList.Items {
+			candidate := &podList.Items[i]
+			if candidate.Status.Phase != corev1.PodRunning || candidate.DeletionTimestamp != nil {
+				continue
+			}
+			if pod == nil || (!podutils.IsPodReady(pod) && podutils.IsPodReady(candidate)) {
+				pod = candidate
+			}
+		}
+		if pod == nil {
+			return nil, nil, fmt.Errorf("no running pods found for service '%s'", key)
+		}
+
+		podPorts, err := translatePorts(ports, func(remotePort string) (int32, error) {
+			return util.LookupServicePortNumberByName(*svc, remotePort)
+		}, func(servicePort int32) (int32, error) {
+			return util.LookupContainerPortNumberByServicePort(*svc, *pod, servicePort)
+		})
+		return pod, podPorts, err
+
+	default:
+		return nil, nil, fmt.Errorf("unable to forward ports to %T, only Pods and Services are supported", target)
+	}
+}
+
+// translatePorts converts port specifications (`[LOCAL_PORT:]REMOTE_PORT`) to use numeric remote ports. Named remote
+// ports are resolved with `lookupName` and, if provided, all remote ports are then mapped with `mapPort`.
+func translatePorts(ports []string, lookupName func(string) (int32, error), mapPort func(int32) (int32, error)) ([]string, error) {
+	translated := []string{}
+	for _, port := range ports {
+		localPort, remotePort := port, port
+		if parts := strings.Split(port, ":"); len(parts) == 2 {
+			localPort, remotePort = parts[0], parts[1]
+		} else if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid port format '%s'", port)
+		}
+		if remotePort == "" {
+			return nil, fmt.Errorf("remote port cannot be empty in '%s'", port)
+		}
+
+		portNum, err := strconv.Atoi(remotePort)
+		if err != nil {
+			namedPort, err := lookupName(remotePort)
+			if err != nil {
+				return nil, err
+			}
+			portNum = int(namedPort)
+			if localPort == remotePort {
+				localPort = strconv.Itoa(portNum)
+			}
+		}
+		if mapPort != nil {
+			targetPort, err := mapPort(int32(portNum)) // #nosec G115
+			if err != nil {
+				return nil, err
+			}
+			portNum = int(targetPort)
+		}
+
+		translated = append(translated, fmt.Sprintf("%s:%d", localPort, portNum))
+	}
+	return translated, nil
+}
+
+// logWriter is an io.Writer that logs everything written to it
+type logWriter struct{}
+
+func (logWriter) Write(p []byte) (int, error) {
+	if message := strings.TrimSpace(string(p)); message != "" {
+		logger.Log("%s", message)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPortForwardPod(name string, phase corev1.PodPhase, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "example"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}, {Name: "metrics", ContainerPort: 9090}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      phase,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func TestGetPortForwardPod(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "example"},
+			Ports: []corev1.ServicePort{
+				{Name: "web", Port: 80, TargetPort: intstr.FromString("http")},
+				{Name: "metrics", Port: 9090, TargetPort: intstr.FromInt32(9090)},
+			},
+		},
+	}
+	c := &Client{Client: fake.NewClientBuilder().WithObjects(
+		service,
+		newPortForwardPod("pending", corev1.PodPending, false),
+		newPortForwardPod("not-ready", corev1.PodRunning, false),
+		newPortForwardPod("ready", corev1.PodRunning, true),
+	).Build()}
+
+	tests := []struct {
+		name          string
+		target        *corev1.Pod
+		ports         []string
+		expectedPod   string
+		expectedPorts []string
+		expectError   bool
+	}{
+		{
+			name:          "pod with numeric ports",
+			target:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "not-ready", Namespace: "default"}},
+			ports:         []string{"8080", ":9090", "1234:8080"},
+			expectedPod:   "not-ready",
+			expectedPorts: []string{"8080:8080", ":9090", "1234:8080"},
+		},
+		{
+			name:          "pod with named ports",
+			target:        &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default"}},
+			ports:         []string{"http", "0:metrics"},
+			expectedPod:   "ready",
+			expectedPorts: []string{"8080:8080", "0:9090"},
+		},
+		{
+			name:        "pod not running",
+			target:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "default"}},
+			ports:       []string{"8080"},
+			expectError: true,
+		},
+		{
+			name:        "unknown named port",
+			target:      &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default"}},
+			ports:       []string{"unknown"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, ports, err := c.getPortForwardPod(context.Background(), tc.target, tc.ports)
+			if tc.expectError {
+				if err == nil {
+					t.Fatalf("Expected an error to be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if pod.Name != tc.expectedPod {
+				t.Errorf("Pod not as expected. Expected: %s, Actual: %s", tc.expectedPod, pod.Name)
+			}
+			if !reflect.DeepEqual(ports, tc.expectedPorts) {
+				t.Errorf("Ports not as expected. Expected: %v, Actual: %v", tc.expectedPorts, ports)
+			}
+		})
+	}
+
+	t.Run("service", func(t *testing.T) {
+		pod, ports, err := c.getPortForwardPod(context.Background(), &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}, []string{"80", ":web", "metrics"})
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		if pod.Name != "ready" {
+			t.Errorf("Expected a ready pod to be selected. Actual: %s", pod.Name)
+		}
+		expectedPorts := []string{"80:8080", ":8080", "9090:9090"}
+		if !reflect.DeepEqual(ports, expectedPorts) {
+			t.Errorf("Ports not as expected. Expected: %v, Actual: %v", expectedPorts, ports)
+		}
+	})
+
+	t.Run("unsupported target", func(t *testing.T) {
+		_, _, err := c.getPortForwardPod(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}, []string{"80"})
+		if err == nil {
+			t.Errorf("Expected an error to be returned")
+		}
+	})
+}
+
+func TestPortForward(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	// Testing against Kind cluster
+	c, err := New(kindKubeconfig)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-apiserver-test-cluster-control-plane", // This is a static pod in the kind cluster
+			Namespace: "kube-system",
+		},
+	}
+	pf, err := c.PortForward(ctx, pod, ":6443")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	address := pf.Address(6443)
+	if address == "" || len(pf.Addresses()) != 1 {
+		t.Fatalf("Expected a local address to be returned. Actual: %v", pf.Addresses())
+	}
+
+	conn, err := tls.Dial("tcp", address, &tls.Config{InsecureSkipVerify: true}) // #nosec G402
+	if err != nil {
+		t.Fatalf("Expected to connect to the forwarded port - %v", err)
+	}
+	conn.Close()
+
+	cancel()
+	select {
+	case <-pf.Done():
+	case <-time.After(10 * time.Second):
+		t.Errorf("Expected port-forward to stop when the context is cancelled")
+	}
+}
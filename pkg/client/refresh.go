@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// RefreshFunc returns a new rest.Config, with fresh credentials, for the cluster a Client is connected to.
+// It is called when the API server rejects the Client's current credentials.
+type RefreshFunc func(ctx context.Context) (*rest.Config, error)
+
+// KubeconfigRefreshFunc returns a RefreshFunc that gets a new kubeconfig for the given workload cluster using the
+// KubeconfigProviders of the provided (Management Cluster) Client, e.g. to get a newly issued Teleport kubeconfig.
+func KubeconfigRefreshFunc(mcClient *Client, clusterName string, clusterNamespace string) RefreshFunc {
+	return func(ctx context.Context) (*rest.Config, error) {
+		kubeconfig, err := mcClient.GetClusterKubeConfig(ctx, clusterName, clusterNamespace)
+		if err != nil {
+			return nil, err
+		}
+
+		return restConfigFromRawKubeconfig([]byte(kubeconfig), "")
+	}
+}
+
+// SetRefreshFunc configures the function used to get new credentials for this Client.
+//
+// When set, any request rejected by the API server with a `401 Unauthorized` causes the Client to call the RefreshFunc,
+// rebuild its connection with the returned rest.Config and retry the request once. This allows long-lived clients
+// (e.g. those using short-lived Teleport certificates) to keep working across credential expiry.
+//
+// Providing nil disables refreshing.
+//
+// Example:
+//
+//	wcClient.SetRefreshFunc(client.KubeconfigRefreshFunc(mcClient, "my-cluster", "org-giantswarm"))
+func (c *Client) SetRefreshFunc(refresh RefreshFunc) {
+	if c.refresher == nil {
+		logger.Log("Client for cluster '%s' doesn't support refreshing credentials", c.clusterName)
+		return
+	}
+	c.refresher.setRefreshFunc(refresh)
+}
+
+// restConfig returns the current rest.Config of the Client, which changes after credentials have been refreshed
+func (c *Client) restConfig() *rest.Config {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.config
+}
+
+func (c *Client) setRestConfig(config *rest.Config) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.config = config
+}
+
+// refreshTransport is an http.RoundTripper that performs requests using the credentials of the current rest.Config,
+// getting new credentials and retrying the request when the current ones are rejected
+type refreshTransport struct {
+	mu         sync.RWMutex
+	base       http.RoundTripper
+	refresh    RefreshFunc
+	generation int
+
+	// refreshMu ensures only a single refresh happens at a time
+	refreshMu sync.Mutex
+	// onRefresh is called with each newly refreshed rest.Config
+	onRefresh func(config *rest.Config)
+}
+
+// newRefreshTransport returns a refreshTransport using the credentials and TLS settings of the provided rest.Config
+func newRefreshTransport(config *rest.Config) (*refreshTransport, error) {
+	base, err := rest.TransportFor(credentialsConfig(config))
+	if err != nil {
+		return nil, err
+	}
+
+	return &refreshTransport{base: base}, nil
+}
+
+func (t *refreshTransport) setRefreshFunc(refresh RefreshFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refresh = refresh
+}
+
+func (t *refreshTransport) current() (http.RoundTripper, RefreshFunc, int) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.base, t.refresh, t.generation
+}
+
+func (t *refreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base, refresh, generation := t.current()
+
+	resp, err := base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || refresh == nil {
+		return resp, err
+	}
+	// We can only retry requests we're able to send the body of again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, err
+	}
+
+	logger.Log("Request %s %s was unauthorized, refreshing credentials", req.Method, req.URL.Path)
+	if refreshErr := t.refreshCredentials(req.Context(), refresh, generation); refreshErr != nil {
+		logger.Log("Failed to refresh credentials: %v", refreshErr)
+		return resp, err
+	}
+
+	// Drain and close the body so the connection can be reused.
+	if resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	retryReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retryReq.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	base, _, _ = t.current()
+	return base.RoundTrip(retryReq)
+}
+
+// refreshCredentials gets a new rest.Config and rebuilds the underlying transport from it. If the credentials have
+// already been refreshed since the given generation (e.g. by a concurrent request) nothing is done.
+func (t *refreshTransport) refreshCredentials(ctx context.Context, refresh RefreshFunc, generation int) error {
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+
+	if _, _, current := t.current(); current != generation {
+		return nil
+	}
+
+	config, err := refresh(ctx)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("no rest config returned")
+	}
+
+	base, err := rest.TransportFor(credentialsConfig(config))
+	if err != nil {
+		return fmt.Errorf("failed to create transport - %v", err)
+	}
+
+	t.mu.Lock()
+	t.base = base
+	t.generation++
+	t.mu.Unlock()
+
+	if t.onRefresh != nil {
+		t.onRefresh(config)
+	}
+
+	return nil
+}
+
+// credentialsConfig returns a copy of the rest.Config containing only what is needed to build the transport that
+// handles TLS and authentication
+func credentialsConfig(config *rest.Config) *rest.Config {
+	credentials := rest.CopyConfig(config)
+	credentials.WrapTransport = nil
+	credentials.Impersonate = rest.ImpersonationConfig{}
+	credentials.UserAgent = ""
+	return credentials
+}
+
+// connectionConfig returns a copy of the rest.Config that uses the provided transport for TLS and authentication,
+// keeping all other settings (such as QPS, Burst and transport wrappers)
+func connectionConfig(config *rest.Config, transport http.RoundTripper) *rest.Config {
+	connection := rest.CopyConfig(config)
+	connection.TLSClientConfig = rest.TLSClientConfig{}
+	connection.BearerToken = ""
+	connection.BearerTokenFile = ""
+	connection.Username = ""
+	connection.Password = ""
+	connection.AuthProvider = nil
+	connection.AuthConfigPersister = nil
+	connection.ExecProvider = nil
+	connection.Proxy = nil
+	connection.Dial = nil
+	connection.Transport = transport
+	return connection
+}
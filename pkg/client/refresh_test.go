@@ -0,0 +1,188 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer is a minimal stand-in for a Kubernetes API server that only serves a single ConfigMap and only
+// accepts requests using the currently valid bearer token
+type fakeAPIServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	validToken string
+}
+
+func newFakeAPIServer(t *testing.T, validToken string) *fakeAPIServer {
+	server := &fakeAPIServer{validToken: validToken}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"kind":"APIVersions","versions":["v1"]}`)
+	})
+	mux.HandleFunc("/apis", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"kind":"APIGroupList","apiVersion":"v1","groups":[]}`)
+	})
+	mux.HandleFunc("/api/v1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"kind":"APIResourceList","groupVersion":"v1","resources":[{"name":"configmaps","singularName":"configmap","namespaced":true,"kind":"ConfigMap","verbs":["get","list"]}]}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/default/configmaps/example", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"example","namespace":"default"},"data":{"hello":"world"}}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/default/pods/example", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Pod","apiVersion":"v1","metadata":{"name":"example","namespace":"default"},`+
+			`"spec":{"initContainers":[{"name":"init"}],"containers":[{"name":"app"},{"name":"sidecar"}]},`+
+			`"status":{"containerStatuses":[{"name":"app","restartCount":2},{"name":"sidecar","restartCount":0}]}}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/default/pods/example/log", serveFakeLogs)
+	mux.HandleFunc("/api/v1/namespaces/default/services/", func(w http.ResponseWriter, r *http.Request) {
+		// Echo back the proxied request
+		fmt.Fprintf(w, "%s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+	})
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		valid := r.Header.Get("Authorization") == "Bearer "+server.validToken
+		server.mu.Unlock()
+
+		if !valid {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// rotateToken invalidates the current token, as happens when short-lived credentials expire
+func (s *fakeAPIServer) rotateToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validToken = token
+}
+
+func (s *fakeAPIServer) restConfig(token string) *rest.Config {
+	return &rest.Config{
+		Host:        s.URL,
+		BearerToken: token,
+	}
+}
+
+func getExampleConfigMap(c *Client) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(context.Background(), types.NamespacedName{Name: "example", Namespace: "default"}, cm)
+	return cm, err
+}
+
+func TestRefreshFunc(t *testing.T) {
+	server := newFakeAPIServer(t, "token-1")
+
+	refreshCalls := 0
+	c, err := newClient(server.restConfig("token-1"), "test", Options{
+		Refresh: func(_ context.Context) (*rest.Config, error) {
+			refreshCalls++
+			return server.restConfig("token-2"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	cm, err := getExampleConfigMap(c)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if cm.Data["hello"] != "world" {
+		t.Errorf("ConfigMap not as expected. Actual: %v", cm.Data)
+	}
+	if refreshCalls != 0 {
+		t.Errorf("Not expecting credentials to be refreshed while valid. Actual refreshes: %d", refreshCalls)
+	}
+
+	// Expire the current credentials
+	server.rotateToken("token-2")
+
+	_, err = getExampleConfigMap(c)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned after refreshing credentials - %v", err)
+	}
+	if refreshCalls != 1 {
+		t.Errorf("Expected credentials to be refreshed once. Actual refreshes: %d", refreshCalls)
+	}
+	if c.restConfig().BearerToken != "token-2" {
+		t.Errorf("Expected the client's rest config to be updated. Actual token: %s", c.restConfig().BearerToken)
+	}
+
+	// The refreshed credentials continue to be used
+	_, err = getExampleConfigMap(c)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if refreshCalls != 1 {
+		t.Errorf("Not expecting credentials to be refreshed again. Actual refreshes: %d", refreshCalls)
+	}
+}
+
+func TestRefreshFunc_NotSet(t *testing.T) {
+	server := newFakeAPIServer(t, "token-1")
+
+	c, err := newClient(server.restConfig("token-1"), "test", Options{})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if _, err := getExampleConfigMap(c); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	server.rotateToken("token-2")
+
+	_, err = getExampleConfigMap(c)
+	if !errors.IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error. Actual: %v", err)
+	}
+
+	// Refreshing can be enabled on an existing client
+	c.SetRefreshFunc(func(_ context.Context) (*rest.Config, error) {
+		return server.restConfig("token-2"), nil
+	})
+	if _, err := getExampleConfigMap(c); err != nil {
+		t.Fatalf("Not expecting an error to be returned after refreshing credentials - %v", err)
+	}
+}
+
+func TestRefreshFunc_Fails(t *testing.T) {
+	server := newFakeAPIServer(t, "token-1")
+
+	c, err := newClient(server.restConfig("token-1"), "test", Options{
+		Refresh: func(_ context.Context) (*rest.Config, error) {
+			return nil, fmt.Errorf("no new credentials available")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	server.rotateToken("token-2")
+
+	_, err = getExampleConfigMap(c)
+	if !errors.IsUnauthorized(err) {
+		t.Errorf("Expected the original unauthorized error. Actual: %v", err)
+	}
+}
@@ -35,9 +35,17 @@ const (
 // is resilient to the two most common sources of E2E flakes: client-side
 // throttling (via QPS/Burst) and transient API/network errors (via a retrying
 // transport). It must be called before the client's transport is built.
-func applyClientResilience(config *rest.Config) {
+//
+// QPS/Burst values set in the provided Options take precedence over the env vars.
+func applyClientResilience(config *rest.Config, opts Options) {
 	config.QPS = float32(envFloat(env.ClientQPS, defaultClientQPS))
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
 	config.Burst = envInt(env.ClientBurst, defaultClientBurst)
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
+	}
 
 	// Compose with any existing wrapper so we don't clobber it.
 	existing := config.WrapTransport
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// ServiceProxyOptions are the options available when creating a ServiceProxy
+type ServiceProxyOptions struct {
+	// Scheme is the scheme used by the API server to connect to the Service, either `http` (default) or `https`
+	Scheme string
+	// Timeout is the timeout of each request made through the ServiceProxy. Defaults to no timeout other than that
+	// of the request context.
+	Timeout time.Duration
+}
+
+// ServiceProxyOption is a function that configures ServiceProxyOptions
+type ServiceProxyOption func(*ServiceProxyOptions)
+
+// WithProxyScheme sets the scheme used by the API server to connect to the Service, e.g. `https`
+func WithProxyScheme(scheme string) ServiceProxyOption {
+	return func(opts *ServiceProxyOptions) {
+		opts.Scheme = scheme
+	}
+}
+
+// WithProxyTimeout sets the timeout of each request made through the ServiceProxy
+func WithProxyTimeout(timeout time.Duration) ServiceProxyOption {
+	return func(opts *ServiceProxyOptions) {
+		opts.Timeout = timeout
+	}
+}
+
+// ServiceProxy makes HTTP requests to a Service via the Kubernetes API server's service proxy
+// (`/api/v1/namespaces/<namespace>/services/<service>:<port>/proxy/`)
+type ServiceProxy struct {
+	httpClient *http.Client
+	baseURL    *url.URL
+}
+
+// ServiceProxy returns a ServiceProxy for making HTTP requests to the given port (either the port number or name) of
+// a Service through the API server. This avoids the need to expose the Service publicly or set up a port-forward.
+//
+// Requests are made with the Client's credentials (including refreshing them if a `RefreshFunc` is set) and
+// idempotent requests are retried on transient errors in the same way as all other requests made by the Client.
+//
+// Example:
+//
+//	proxy, err := wcClient.ServiceProxy("monitoring", "prometheus", "9090")
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := proxy.Get(ctx, "/api/v1/query?query=up")
+func (c *Client) ServiceProxy(namespace string, serviceName string, port string, opts ...ServiceProxyOption) (*ServiceProxy, error) {
+	options := &ServiceProxyOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Timeout = options.Timeout
+
+	hostURL, _, err := rest.DefaultServerUrlFor(c.restConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API server URL - %v", err)
+	}
+
+	service := serviceName
+	if port != "" {
+		service = fmt.Sprintf("%s:%s", service, port)
+	}
+	if options.Scheme != "" {
+		service = fmt.Sprintf("%s:%s", options.Scheme, service)
+	}
+
+	baseURL := *hostURL
+	baseURL.Path = path.Join(hostURL.Path, "/api/v1/namespaces", namespace, "services", service, "proxy")
+
+	return &ServiceProxy{
+		httpClient: httpClient,
+		baseURL:    &baseURL,
+	}, nil
+}
+
+// URL returns the full URL, via the API server, of the given path (which may include a query string) on the Service
+func (p *ServiceProxy) URL(requestPath string) string {
+	requestURL := *p.baseURL
+	query := ""
+	if i := strings.Index(requestPath, "?"); i >= 0 {
+		requestPath, query = requestPath[:i], requestPath[i+1:]
+	}
+	requestURL.Path = strings.TrimSuffix(p.baseURL.Path, "/") + "/" + strings.TrimPrefix(requestPath, "/")
+	requestURL.RawQuery = query
+	return requestURL.String()
+}
+
+// HTTPClient returns the http.Client used to make requests through the API server. It can be used directly with
+// URLs returned by `URL`.
+func (p *ServiceProxy) HTTPClient() *http.Client {
+	return p.httpClient
+}
+
+// NewRequest returns a new http.Request for the given path on the Service
+func (p *ServiceProxy) NewRequest(ctx context.Context, method string, requestPath string, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, p.URL(requestPath), body)
+}
+
+// Do sends the request through the API server
+func (p *ServiceProxy) Do(req *http.Request) (*http.Response, error) {
+	return p.httpClient.Do(req)
+}
+
+// Get performs a GET request for the given path on the Service. The caller must close the returned response body.
+func (p *ServiceProxy) Get(ctx context.Context, requestPath string) (*http.Response, error) {
+	req, err := p.NewRequest(ctx, http.MethodGet, requestPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return p.Do(req)
+}
+
+// httpClient returns a new http.Client that makes requests to the API server in the same way as the Client
+func (c *Client) httpClient() (*http.Client, error) {
+	config := c.restConfig()
+	if c.refresher != nil {
+		config = connectionConfig(config, c.refresher)
+	}
+
+	httpClient, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client - %v", err)
+	}
+	return httpClient, nil
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestServiceProxy_URL(t *testing.T) {
+	c := &Client{config: &rest.Config{Host: "https://api.example.com:6443"}}
+
+	tests := []struct {
+		name        string
+		port        string
+		opts        []ServiceProxyOption
+		requestPath string
+		expected    string
+	}{
+		{
+			name:        "port number",
+			port:        "9090",
+			requestPath: "/api/v1/query?query=up",
+			expected:    "https://api.example.com:6443/api/v1/namespaces/monitoring/services/prometheus:9090/proxy/api/v1/query?query=up",
+		},
+		{
+			name:        "named port without leading slash",
+			port:        "web",
+			requestPath: "healthz",
+			expected:    "https://api.example.com:6443/api/v1/namespaces/monitoring/services/prometheus:web/proxy/healthz",
+		},
+		{
+			name:        "https scheme",
+			port:        "443",
+			opts:        []ServiceProxyOption{WithProxyScheme("https")},
+			requestPath: "/",
+			expected:    "https://api.example.com:6443/api/v1/namespaces/monitoring/services/https:prometheus:443/proxy/",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			proxy, err := c.ServiceProxy("monitoring", "prometheus", tc.port, tc.opts...)
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if actual := proxy.URL(tc.requestPath); actual != tc.expected {
+				t.Errorf("URL not as expected.\nExpected: %s\nActual:   %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestServiceProxy_Get(t *testing.T) {
+	server := newFakeAPIServer(t, "token-1")
+
+	c, err := newClient(server.restConfig("token-1"), "test", Options{
+		Refresh: func(_ context.Context) (*rest.Config, error) {
+			return server.restConfig("token-2"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	proxy, err := c.ServiceProxy("default", "example", "http")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	get := func() string {
+		resp, err := proxy.Get(context.Background(), "/metrics?format=text")
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected a successful response. Actual: %d", resp.StatusCode)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		return string(body)
+	}
+
+	expected := "GET /api/v1/namespaces/default/services/example:http/proxy/metrics?format=text"
+	if actual := get(); actual != expected {
+		t.Errorf("Proxied request not as expected.\nExpected: %s\nActual:   %s", expected, actual)
+	}
+
+	// Requests continue to work once the client's credentials have been refreshed
+	server.rotateToken("token-2")
+	if actual := get(); !strings.HasPrefix(actual, "GET ") {
+		t.Errorf("Expected request to succeed after refreshing credentials. Actual: %s", actual)
+	}
+}
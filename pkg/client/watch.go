@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/watch"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Watch starts a watch on the objects of the kind of the provided list, filtered by the provided list options.
+// The returned watch must be stopped by the caller.
+//
+// Example:
+//
+//	watcher, err := client.Watch(ctx, &appsv1.DeploymentList{}, cr.InNamespace("default"))
+//	if err != nil {
+//		return err
+//	}
+//	defer watcher.Stop()
+//	for event := range watcher.ResultChan() {
+//		// handle event
+//	}
+func (c *Client) Watch(ctx context.Context, list cr.ObjectList, opts ...cr.ListOption) (watch.Interface, error) {
+	crClient := c.Client
+	if hooked, ok := crClient.(*hookedClient); ok {
+		crClient = hooked.Client
+	}
+
+	watchClient, ok := crClient.(cr.WithWatch)
+	if !ok {
+		return nil, fmt.Errorf("client doesn't support watching")
+	}
+
+	return watchClient.Watch(ctx, list, opts...)
+}
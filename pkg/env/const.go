@@ -45,4 +45,15 @@ const (
 	// ClientBurst overrides the client-go Burst used by the Kubernetes clients.
 	// Must parse as an int; invalid values fall back to the default.
 	ClientBurst = "E2E_CLIENT_BURST"
+
+	// VersionsFile is the environment variable pointing to a local file containing
+	// the app and release versions to use. When set, versions are never looked up
+	// over the network and building a cluster's Release CR returns an error.
+	VersionsFile = "E2E_VERSIONS_FILE"
+	// VersionsCacheDir is the environment variable containing the directory to
+	// cache app and release versions looked up from GitHub in.
+	VersionsCacheDir = "E2E_VERSIONS_CACHE_DIR"
+	// VersionsCacheTTL is the environment variable containing how long cached
+	// versions are used for before being looked up again. E.g. `6h`
+	VersionsCacheTTL = "E2E_VERSIONS_CACHE_TTL"
 )
@@ -0,0 +1,23 @@
+// package events provides a Recorder that continuously watches Events on Management and Workload Clusters during a
+// test run, keeping them (deduplicated, with counts) in memory and in an artifact file so they are still available
+// to failure handlers after the Events themselves have aged out of the cluster.
+//
+// By default only Events of type `Warning` are recorded.
+//
+// # Example
+//
+//	recorder := framework.EnableEventRecording(events.WithArtifactFile("/tmp/artifacts/events.json"))
+//	defer recorder.Stop()
+//
+//	// Apply clusters and run tests...
+//
+//	for _, event := range recorder.Warnings("Pod", testStart) {
+//		fmt.Printf("%s/%s: %s - %s (x%d)\n", event.Namespace, event.Name, event.Reason, event.Message, event.Count)
+//	}
+//
+// # Using without a Framework
+//
+//	recorder := events.NewRecorder(events.WithEventTypes(corev1.EventTypeNormal, corev1.EventTypeWarning))
+//	defer recorder.Stop()
+//	recorder.Watch(wcClient, "my-cluster", "")
+package events
@@ -0,0 +1,488 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	watchtools "k8s.io/client-go/tools/watch"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+const (
+	// DefaultFlushInterval is how often recorded events are written to the artifact file, if configured
+	DefaultFlushInterval = 30 * time.Second
+
+	// watchRetryInterval is how long to wait before restarting a failed watch
+	watchRetryInterval = 10 * time.Second
+)
+
+// Event is a deduplicated Event recorded from a cluster
+type Event struct {
+	// Cluster is the name of the cluster the Event was recorded from
+	Cluster string `json:"cluster"`
+	// Namespace, Kind and Name identify the object the Event is about
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Source    string `json:"source,omitempty"`
+	// Count is the number of times the Event has occurred since it was first seen
+	Count     int32     `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// Query filters recorded Events. Empty fields match all Events.
+type Query struct {
+	Cluster   string
+	Namespace string
+	Kind      string
+	Name      string
+	Type      string
+	Reason    string
+	// Since only matches Events last seen at or after the given time
+	Since time.Time
+}
+
+func (q Query) matches(event *Event) bool {
+	return (q.Cluster == "" || q.Cluster == event.Cluster) &&
+		(q.Namespace == "" || q.Namespace == event.Namespace) &&
+		(q.Kind == "" || strings.EqualFold(q.Kind, event.Kind)) &&
+		(q.Name == "" || q.Name == event.Name) &&
+		(q.Type == "" || q.Type == event.Type) &&
+		(q.Reason == "" || q.Reason == event.Reason) &&
+		(q.Since.IsZero() || !event.LastSeen.Before(q.Since))
+}
+
+// Option is a function that configures a Recorder
+type Option func(*Recorder)
+
+// WithArtifactFile writes all recorded Events, as JSON, to the file at the given path periodically and when the
+// Recorder is stopped
+func WithArtifactFile(path string) Option {
+	return func(r *Recorder) {
+		r.artifactPath = path
+	}
+}
+
+// WithFlushInterval sets how often the recorded Events are written to the artifact file.
+// Defaults to `DefaultFlushInterval`.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(r *Recorder) {
+		r.flushInterval = interval
+	}
+}
+
+// WithEventTypes sets the types of Events to record. Defaults to only `Warning` Events.
+func WithEventTypes(eventTypes ...string) Option {
+	return func(r *Recorder) {
+		r.eventTypes = eventTypes
+	}
+}
+
+// Recorder watches Events on one or more clusters and records them. It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.RWMutex
+	events []*Event
+	index  map[string]*Event
+	// seen holds the last known count of each Event object so repeated updates are only counted once
+	seen  map[types.UID]int32
+	dirty bool
+
+	eventTypes    []string
+	artifactPath  string
+	flushInterval time.Duration
+
+	watchMu sync.Mutex
+	// watches holds the function to stop each watch, keyed by cluster and namespace
+	watches map[string]context.CancelFunc
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewRecorder returns a new Recorder. Call `Watch` to start recording Events from a cluster and `Stop` once finished.
+func NewRecorder(opts ...Option) *Recorder {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &Recorder{
+		index:         map[string]*Event{},
+		seen:          map[types.UID]int32{},
+		eventTypes:    []string{corev1.EventTypeWarning},
+		flushInterval: DefaultFlushInterval,
+		watches:       map[string]context.CancelFunc{},
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.artifactPath != "" && r.flushInterval > 0 {
+		r.wg.Add(1)
+		go r.flushPeriodically()
+	}
+
+	return r
+}
+
+// Watch starts recording Events, in the background, from the given namespace (or all namespaces if empty) of the
+// cluster the provided Client is connected to. Existing Events are recorded first.
+//
+// The watch is restarted if it fails and continues until `Unwatch` or `Stop` is called. Watching the same cluster and
+// namespace more than once has no effect.
+func (r *Recorder) Watch(c *client.Client, clusterName string, namespace string) {
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+
+	key := watchKey(clusterName, namespace)
+	if _, ok := r.watches[key]; ok || r.ctx.Err() != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	r.watches[key] = cancel
+
+	logger.Log("Recording events from cluster '%s' (namespace: '%s')", clusterName, namespace)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			err := r.watch(ctx, c, clusterName, namespace)
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Log("Watching events from cluster '%s' failed, retrying in %s: %v", clusterName, watchRetryInterval, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryInterval):
+			}
+		}
+	}()
+}
+
+// Unwatch stops recording Events from the given cluster and namespace, as previously passed to `Watch`, e.g. once the
+// cluster has been deleted. The Events already recorded remain available to query.
+func (r *Recorder) Unwatch(clusterName string, namespace string) {
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+
+	key := watchKey(clusterName, namespace)
+	cancel, ok := r.watches[key]
+	if !ok {
+		return
+	}
+	cancel()
+	delete(r.watches, key)
+
+	logger.Log("Stopped recording events from cluster '%s' (namespace: '%s')", clusterName, namespace)
+}
+
+func watchKey(clusterName string, namespace string) string {
+	return fmt.Sprintf("%s/%s", clusterName, namespace)
+}
+
+// watch lists and then watches Events until the watch fails or the context is done
+func (r *Recorder) watch(ctx context.Context, c *client.Client, clusterName string, namespace string) error {
+	listOpts := []cr.ListOption{}
+	if namespace != "" {
+		listOpts = append(listOpts, cr.InNamespace(namespace))
+	}
+	fieldSelector := r.fieldSelector()
+	if fieldSelector != nil {
+		listOpts = append(listOpts, cr.MatchingFieldsSelector{Selector: fieldSelector})
+	}
+
+	list := &corev1.EventList{}
+	if err := c.List(ctx, list, listOpts...); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		r.Record(clusterName, &list.Items[i])
+	}
+
+	watcher, err := watchtools.NewRetryWatcherWithContext(ctx, list.ResourceVersion, &eventWatcher{
+		client:        c,
+		namespace:     namespace,
+		fieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case result, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("watch closed")
+			}
+
+			switch result.Type {
+			case watch.Added, watch.Modified:
+				if event, ok := result.Object.(*corev1.Event); ok {
+					r.Record(clusterName, event)
+				}
+			case watch.Error:
+				return apierrors.FromObject(result.Object)
+			}
+		}
+	}
+}
+
+// fieldSelector returns the field selector to use when only a single type of Event is being recorded
+func (r *Recorder) fieldSelector() fields.Selector {
+	if len(r.eventTypes) != 1 {
+		return nil
+	}
+	return fields.OneTermEqualSelector("type", r.eventTypes[0])
+}
+
+// eventWatcher adapts a Client to the watcher used by the RetryWatcher
+type eventWatcher struct {
+	client        *client.Client
+	namespace     string
+	fieldSelector fields.Selector
+}
+
+func (w *eventWatcher) WatchWithContext(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	if w.fieldSelector != nil {
+		opts.FieldSelector = w.fieldSelector.String()
+	}
+	return w.client.WatchEvents(ctx, w.namespace, opts)
+}
+
+// Record adds the given Event, from the given cluster, to the recorded Events. Events with the same involved object,
+// type, reason and message are deduplicated with their counts combined.
+//
+// Events not of a recorded type are ignored.
+func (r *Recorder) Record(clusterName string, event *corev1.Event) {
+	if !r.isRecordedType(event.Type) {
+		return
+	}
+
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	firstSeen, lastSeen := eventTimes(event)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := strings.Join([]string{
+		clusterName,
+		event.InvolvedObject.Namespace,
+		event.InvolvedObject.Kind,
+		event.InvolvedObject.Name,
+		event.Type,
+		event.Reason,
+		event.Message,
+	}, "|")
+
+	// Only the occurrences not already recorded for this Event object are added
+	delta := count
+	if previous, ok := r.seen[event.UID]; ok && event.UID != "" {
+		delta = count - previous
+	}
+	if event.UID != "" && count > r.seen[event.UID] {
+		r.seen[event.UID] = count
+	}
+
+	recorded, ok := r.index[key]
+	if !ok {
+		source := event.Source.Component
+		if source == "" {
+			source = event.ReportingController
+		}
+		recorded = &Event{
+			Cluster:   clusterName,
+			Namespace: event.InvolvedObject.Namespace,
+			Kind:      event.InvolvedObject.Kind,
+			Name:      event.InvolvedObject.Name,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Source:    source,
+			FirstSeen: firstSeen,
+			LastSeen:  lastSeen,
+		}
+		r.index[key] = recorded
+		r.events = append(r.events, recorded)
+	}
+
+	if delta > 0 {
+		recorded.Count += delta
+		r.dirty = true
+	}
+	if firstSeen.Before(recorded.FirstSeen) {
+		recorded.FirstSeen = firstSeen
+		r.dirty = true
+	}
+	if lastSeen.After(recorded.LastSeen) {
+		recorded.LastSeen = lastSeen
+		r.dirty = true
+	}
+}
+
+func (r *Recorder) isRecordedType(eventType string) bool {
+	if len(r.eventTypes) == 0 {
+		return true
+	}
+	for _, t := range r.eventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTimes returns when the Event was first and last seen, using whichever timestamps are set on it
+func eventTimes(event *corev1.Event) (time.Time, time.Time) {
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = event.EventTime.Time
+	}
+	if firstSeen.IsZero() {
+		firstSeen = event.CreationTimestamp.Time
+	}
+	if firstSeen.IsZero() {
+		firstSeen = time.Now()
+	}
+
+	lastSeen := event.LastTimestamp.Time
+	if event.Series != nil && event.Series.LastObservedTime.After(lastSeen) {
+		lastSeen = event.Series.LastObservedTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = firstSeen
+	}
+
+	return firstSeen, lastSeen
+}
+
+// Events returns all recorded Events matching the query, ordered by when they were last seen
+func (r *Recorder) Events(query Query) []Event {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := []Event{}
+	for _, event := range r.events {
+		if query.matches(event) {
+			matched = append(matched, *event)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].LastSeen.Before(matched[j].LastSeen)
+	})
+
+	return matched
+}
+
+// Warnings returns all recorded Warning Events about objects of the given kind (or all kinds if empty) last seen at or
+// after the given time
+//
+// Example:
+//
+//	podWarnings := recorder.Warnings("Pod", testStart)
+func (r *Recorder) Warnings(kind string, since time.Time) []Event {
+	return r.Events(Query{Kind: kind, Type: corev1.EventTypeWarning, Since: since})
+}
+
+// WriteJSON writes all recorded Events as a JSON array
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r.Events(Query{}))
+}
+
+// Flush writes all recorded Events to the artifact file, if one is configured
+func (r *Recorder) Flush() error {
+	if r.artifactPath == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	r.dirty = false
+	r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(r.artifactPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create events artifact directory: %w", err)
+	}
+
+	// Write to a temporary file first so the artifact is never left partially written
+	tmpFile, err := os.CreateTemp(filepath.Dir(r.artifactPath), filepath.Base(r.artifactPath)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write events artifact file '%s': %w", r.artifactPath, err)
+	}
+	err = r.WriteJSON(tmpFile)
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), r.artifactPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write events artifact file '%s': %w", r.artifactPath, err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) flushPeriodically() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.mu.RLock()
+			dirty := r.dirty
+			r.mu.RUnlock()
+
+			if dirty {
+				if err := r.Flush(); err != nil {
+					logger.Log("Failed to flush recorded events: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// Stop stops all watches and writes the recorded Events to the artifact file, if one is configured.
+// The recorded Events remain available to query.
+func (r *Recorder) Stop() error {
+	r.cancel()
+	r.wg.Wait()
+	return r.Flush()
+}
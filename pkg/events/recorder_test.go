@@ -0,0 +1,174 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+)
+
+var baseTime = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newEvent(uid string, kind string, name string, eventType string, reason string, count int32, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       types.UID(uid),
+			Name:      uid,
+			Namespace: "default",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Name:      name,
+			Namespace: "default",
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " happened",
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(baseTime),
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func TestRecord_Deduplicates(t *testing.T) {
+	r := NewRecorder()
+	defer func() { _ = r.Stop() }()
+
+	// The same Event object being updated only adds the new occurrences
+	r.Record("wc", newEvent("a", "Pod", "example", corev1.EventTypeWarning, "BackOff", 1, baseTime))
+	r.Record("wc", newEvent("a", "Pod", "example", corev1.EventTypeWarning, "BackOff", 3, baseTime.Add(time.Minute)))
+	r.Record("wc", newEvent("a", "Pod", "example", corev1.EventTypeWarning, "BackOff", 3, baseTime.Add(time.Minute)))
+	// A separate Event object with the same details is combined
+	r.Record("wc", newEvent("b", "Pod", "example", corev1.EventTypeWarning, "BackOff", 2, baseTime.Add(2*time.Minute)))
+	// The same Event from a different cluster is kept separate
+	r.Record("mc", newEvent("c", "Pod", "example", corev1.EventTypeWarning, "BackOff", 1, baseTime))
+	// Normal events aren't recorded by default
+	r.Record("wc", newEvent("d", "Pod", "example", corev1.EventTypeNormal, "Pulled", 1, baseTime))
+
+	events := r.Events(Query{})
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 recorded events. Actual: %d - %v", len(events), events)
+	}
+
+	wcEvents := r.Events(Query{Cluster: "wc"})
+	if len(wcEvents) != 1 {
+		t.Fatalf("Expected 1 recorded event for the WC. Actual: %d", len(wcEvents))
+	}
+	if wcEvents[0].Count != 5 {
+		t.Errorf("Count not as expected. Expected: 5, Actual: %d", wcEvents[0].Count)
+	}
+	if !wcEvents[0].FirstSeen.Equal(baseTime) || !wcEvents[0].LastSeen.Equal(baseTime.Add(2*time.Minute)) {
+		t.Errorf("Timestamps not as expected. Actual: %s - %s", wcEvents[0].FirstSeen, wcEvents[0].LastSeen)
+	}
+}
+
+func TestRecord_EventTypes(t *testing.T) {
+	r := NewRecorder(WithEventTypes(corev1.EventTypeNormal, corev1.EventTypeWarning))
+	defer func() { _ = r.Stop() }()
+
+	r.Record("wc", newEvent("a", "Pod", "example", corev1.EventTypeWarning, "BackOff", 1, baseTime))
+	r.Record("wc", newEvent("b", "Pod", "example", corev1.EventTypeNormal, "Pulled", 1, baseTime))
+
+	if events := r.Events(Query{}); len(events) != 2 {
+		t.Errorf("Expected 2 recorded events. Actual: %d", len(events))
+	}
+	if events := r.Events(Query{Type: corev1.EventTypeNormal}); len(events) != 1 {
+		t.Errorf("Expected 1 Normal event. Actual: %d", len(events))
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	r := NewRecorder()
+	defer func() { _ = r.Stop() }()
+
+	r.Record("wc", newEvent("a", "Pod", "old", corev1.EventTypeWarning, "BackOff", 1, baseTime))
+	r.Record("wc", newEvent("b", "Pod", "new", corev1.EventTypeWarning, "BackOff", 1, baseTime.Add(time.Hour)))
+	r.Record("wc", newEvent("c", "Node", "node", corev1.EventTypeWarning, "NotReady", 1, baseTime.Add(time.Hour)))
+
+	warnings := r.Warnings("pod", baseTime.Add(time.Minute))
+	if len(warnings) != 1 || warnings[0].Name != "new" {
+		t.Errorf("Warnings not as expected. Actual: %v", warnings)
+	}
+
+	if warnings := r.Warnings("", time.Time{}); len(warnings) != 3 {
+		t.Errorf("Expected all 3 warnings. Actual: %d", len(warnings))
+	}
+}
+
+func TestStop_WritesArtifactFile(t *testing.T) {
+	artifactPath := filepath.Join(t.TempDir(), "artifacts", "events.json")
+	r := NewRecorder(WithArtifactFile(artifactPath))
+
+	r.Record("wc", newEvent("a", "Pod", "example", corev1.EventTypeWarning, "BackOff", 2, baseTime))
+
+	if err := r.Stop(); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	data, err := os.ReadFile(artifactPath) // #nosec G304
+	if err != nil {
+		t.Fatalf("Expected artifact file to be written - %v", err)
+	}
+	written := []Event{}
+	if err := json.Unmarshal(data, &written); err != nil {
+		t.Fatalf("Failed to parse artifact file - %v", err)
+	}
+	if len(written) != 1 || written[0].Reason != "BackOff" || written[0].Count != 2 {
+		t.Errorf("Artifact file content not as expected. Actual: %v", written)
+	}
+
+	// Events remain available after stopping
+	if events := r.Events(Query{}); len(events) != 1 {
+		t.Errorf("Expected recorded events to remain after stopping. Actual: %d", len(events))
+	}
+}
+
+func TestUnwatch(t *testing.T) {
+	r := NewRecorder()
+	defer func() { _ = r.Stop() }()
+
+	c := &client.Client{Client: fake.NewClientBuilder().Build()}
+	r.Watch(c, "test-cluster", "")
+	r.Watch(c, "test-cluster", "org-test")
+
+	r.Unwatch("test-cluster", "")
+	r.Unwatch("unknown-cluster", "")
+
+	r.watchMu.Lock()
+	_, stillWatching := r.watches["test-cluster/org-test"]
+	watchCount := len(r.watches)
+	r.watchMu.Unlock()
+	if watchCount != 1 || !stillWatching {
+		t.Errorf("Expected only the other watch to remain. Actual: %d watches", watchCount)
+	}
+
+	r.Unwatch("test-cluster", "org-test")
+
+	// All watch goroutines should exit without needing to stop the Recorder
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the watches to stop after calling Unwatch")
+	}
+
+	// The same cluster can be watched again
+	r.Watch(c, "test-cluster", "")
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+	if _, ok := r.watches["test-cluster/"]; !ok {
+		t.Errorf("Expected the cluster to be watched again")
+	}
+}
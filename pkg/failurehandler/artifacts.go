@@ -0,0 +1,28 @@
+package failurehandler
+
+import (
+	"github.com/giantswarm/clustertest/v5"
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/artifacts"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// CollectArtifacts collects debugging artifacts (CAPI objects, App and HelmRelease CRs, the Release CR, events, pod logs
+// and node descriptions) from both the management cluster and workload cluster into a new directory within `dir`.
+// See the `artifacts` package for details.
+func CollectArtifacts(framework *clustertest.Framework, cluster *application.Cluster, dir string, opts ...artifacts.Option) FailureHandler {
+	return Wrap(func() {
+		ctx, cancel := newContext()
+		defer cancel()
+
+		logger.Log("Collecting artifacts for debugging")
+
+		path, err := framework.CollectArtifacts(ctx, cluster, dir, opts...)
+		if err != nil {
+			logger.Log("Failed to collect artifacts - %v", err)
+			return
+		}
+
+		logger.Log("Artifacts written to '%s'", path)
+	})
+}
@@ -0,0 +1,28 @@
+// package janitor provides functions to find and remove resources left behind on a Management Cluster by E2E test
+// runs that didn't get the chance to clean up after themselves (e.g. CI jobs that were killed or timed out).
+//
+// Only resources marked with the `e2e-test-cleanup` annotation are considered. Organizations, cluster Apps and
+// Releases can additionally be filtered by their age and by the `cicd.giantswarm.io/*` labels added during CI runs.
+// Any cluster Apps found in the namespace of an orphaned Organization are removed using
+// [clustertest.Framework.DeleteCluster] before the Organization itself so the teardown order matches that of a normal
+// test run. Organizations containing cluster Apps that aren't orphaned are skipped and reported instead. Orphaned
+// cluster Apps in Organizations that aren't orphaned, or skipped, are removed without their Organization.
+//
+// # Example
+//
+//	framework, err := clustertest.New("context_name")
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	j := janitor.New(framework,
+//		janitor.WithMaxAge(12*time.Hour),
+//		janitor.WithPR("123"),
+//		janitor.WithDryRun(true),
+//	)
+//
+//	report, err := j.Run(ctx)
+//	for _, cluster := range report.Clusters {
+//		fmt.Println("Would delete cluster", cluster)
+//	}
+package janitor
@@ -0,0 +1,340 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	orgv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	capi "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5"
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
+)
+
+const (
+	// DefaultMaxAge is the minimum age a resource must be before it is considered orphaned if a max age is not provided
+	DefaultMaxAge = 24 * time.Hour
+
+	// PipelineRunLabel is the label containing the Tekton PipelineRun that created a resource
+	PipelineRunLabel = "cicd.giantswarm.io/pipelinerun"
+	// PRLabel is the label containing the pull request number that triggered the creation of a resource
+	PRLabel = "cicd.giantswarm.io/pr"
+)
+
+// Options are the options available when cleaning up orphaned resources
+type Options struct {
+	MaxAge      time.Duration
+	PipelineRun string
+	PR          string
+	DryRun      bool
+}
+
+// Option is a function that can be optionally provided to override default options of the Janitor
+type Option func(*Options)
+
+// WithMaxAge overrides the minimum age a resource must be before it is considered orphaned
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(options *Options) {
+		options.MaxAge = maxAge
+	}
+}
+
+// WithPipelineRun limits the cleanup to resources created by the given Tekton PipelineRun
+func WithPipelineRun(pipelineRun string) Option {
+	return func(options *Options) {
+		options.PipelineRun = pipelineRun
+	}
+}
+
+// WithPR limits the cleanup to resources created for the given pull request number
+func WithPR(pr string) Option {
+	return func(options *Options) {
+		options.PR = pr
+	}
+}
+
+// WithDryRun sets whether resources should only be reported instead of deleted
+func WithDryRun(dryRun bool) Option {
+	return func(options *Options) {
+		options.DryRun = dryRun
+	}
+}
+
+// Report contains the resources found to be orphaned. If not running in dry-run mode these have also been deleted,
+// except for those listed in Errors.
+type Report struct {
+	Organizations []string
+	Clusters      []string
+	Releases      []string
+	Errors        []error
+
+	// SkippedOrganizations are orphaned Organizations that weren't deleted as they contain clusters still in use
+	SkippedOrganizations []string
+}
+
+// managementCluster is the subset of the Framework used by the Janitor
+type managementCluster interface {
+	MC() *client.Client
+	DeleteCluster(ctx context.Context, cluster *application.Cluster, opts ...clustertest.DeleteOption) error
+	DeleteOrg(ctx context.Context, org *organization.Org) error
+}
+
+// Janitor finds and removes orphaned E2E resources from a Management Cluster
+type Janitor struct {
+	framework managementCluster
+	options   *Options
+}
+
+// New returns a new Janitor that will clean up orphaned resources on the Management Cluster of the provided Framework
+func New(framework *clustertest.Framework, opts ...Option) *Janitor {
+	options := &Options{
+		MaxAge: DefaultMaxAge,
+	}
+	for _, optFn := range opts {
+		optFn(options)
+	}
+
+	return &Janitor{
+		framework: framework,
+		options:   options,
+	}
+}
+
+// Run finds all orphaned resources matching the Janitor's options and deletes them, unless in dry-run mode.
+//
+// Orphaned Organizations are removed after all cluster Apps found in their namespace have been deleted. If any of
+// those cluster Apps isn't orphaned itself the Organization is skipped and listed in the Report instead. Orphaned
+// cluster Apps in Organizations that are still in use are then removed on their own, followed by any remaining orphaned
+// Releases whose Cluster no longer exists.
+//
+// As the Janitor only ever removes resources marked as safe to delete the `E2E_WC_KEEP` env var is ignored.
+//
+// The returned Report lists all resources found. Failures to delete individual resources don't stop the run, instead
+// they are recorded in the Report and returned as an aggregated error.
+func (j *Janitor) Run(ctx context.Context) (*Report, error) {
+	report := &Report{}
+
+	orgList := &orgv1alpha1.OrganizationList{}
+	if err := j.framework.MC().List(ctx, orgList, j.listOptions()...); err != nil {
+		return report, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	orphanedNamespaces := map[string]bool{}
+	for i := range orgList.Items {
+		orgCR := &orgList.Items[i]
+		if !j.isOrphaned(orgCR) {
+			continue
+		}
+
+		org := organization.New(orgCR.Name)
+		if orgCR.Status.Namespace != "" {
+			org = organization.NewFromNamespace(orgCR.Status.Namespace)
+		}
+
+		clusters, inUse, err := j.findClusters(ctx, org)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("failed to find clusters for organization '%s': %w", org.Name, err))
+			continue
+		}
+		if len(inUse) > 0 {
+			// Any orphaned clusters in this namespace are removed on their own below
+			logger.Log("Skipping orphaned Organization '%s' as it contains clusters still in use: %v", org.Name, inUse)
+			report.SkippedOrganizations = append(report.SkippedOrganizations, org.Name)
+			continue
+		}
+		orphanedNamespaces[org.GetNamespace()] = true
+
+		report.Organizations = append(report.Organizations, org.Name)
+		for _, cluster := range clusters {
+			report.Clusters = append(report.Clusters, fmt.Sprintf("%s/%s", cluster.GetNamespace(), cluster.Name))
+		}
+
+		if j.options.DryRun {
+			logger.Log("[dry-run] Would delete Organization '%s' and its %d cluster(s)", org.Name, len(clusters))
+			continue
+		}
+
+		err = j.deleteOrg(ctx, org, clusters)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("failed to delete organization '%s': %w", org.Name, err))
+		}
+	}
+
+	err := j.deleteClusters(ctx, orphanedNamespaces, report)
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	err = j.deleteReleases(ctx, report)
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	return report, utilerrors.NewAggregate(report.Errors)
+}
+
+// deleteOrg removes each of the given clusters, waiting for each to be fully deleted, followed by the Organization
+func (j *Janitor) deleteOrg(ctx context.Context, org *organization.Org, clusters []*application.Cluster) error {
+	for _, cluster := range clusters {
+		logger.Log("Deleting orphaned Cluster '%s/%s'", cluster.GetNamespace(), cluster.Name)
+		// The Organization is only removed once all its clusters are gone
+		err := j.framework.DeleteCluster(ctx, cluster, clustertest.WithoutOrgDeletion(), clustertest.WithIgnoreKeepWorkloadCluster())
+		if err != nil {
+			return fmt.Errorf("failed to delete cluster '%s': %w", cluster.Name, err)
+		}
+	}
+
+	logger.Log("Deleting orphaned Organization '%s'", org.Name)
+	return j.framework.DeleteOrg(ctx, org)
+}
+
+// deleteClusters removes all orphaned cluster Apps outside of the provided, already handled, orphaned namespaces.
+// The Organizations of these clusters are kept as they aren't orphaned themselves.
+func (j *Janitor) deleteClusters(ctx context.Context, orphanedNamespaces map[string]bool, report *Report) error {
+	appList := &applicationv1alpha1.AppList{}
+	if err := j.framework.MC().List(ctx, appList, j.listOptions()...); err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+
+	for i := range appList.Items {
+		app := &appList.Items[i]
+		if orphanedNamespaces[app.Namespace] || !j.isOrphaned(app) {
+			continue
+		}
+		provider := application.ProviderFromClusterApplication(app)
+		if provider == application.ProviderUnknown {
+			continue
+		}
+
+		cluster := &application.Cluster{
+			Name:         app.Name,
+			Provider:     provider,
+			Organization: organization.NewFromNamespace(app.Namespace),
+		}
+		report.Clusters = append(report.Clusters, fmt.Sprintf("%s/%s", cluster.GetNamespace(), cluster.Name))
+
+		if j.options.DryRun {
+			logger.Log("[dry-run] Would delete Cluster '%s/%s'", cluster.GetNamespace(), cluster.Name)
+			continue
+		}
+
+		logger.Log("Deleting orphaned Cluster '%s/%s'", cluster.GetNamespace(), cluster.Name)
+		err := j.framework.DeleteCluster(ctx, cluster, clustertest.WithoutOrgDeletion(), clustertest.WithIgnoreKeepWorkloadCluster())
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("failed to delete cluster '%s': %w", cluster.Name, err))
+		}
+	}
+
+	return nil
+}
+
+// findClusters returns the orphaned clusters found, based on their cluster App, in the namespace of the provided Org
+// along with the names of any clusters that aren't orphaned
+func (j *Janitor) findClusters(ctx context.Context, org *organization.Org) ([]*application.Cluster, []string, error) {
+	appList := &applicationv1alpha1.AppList{}
+	if err := j.framework.MC().List(ctx, appList, cr.InNamespace(org.GetNamespace())); err != nil {
+		return nil, nil, err
+	}
+
+	clusters := []*application.Cluster{}
+	inUse := []string{}
+	for i := range appList.Items {
+		app := &appList.Items[i]
+		provider := application.ProviderFromClusterApplication(app)
+		if provider == application.ProviderUnknown {
+			continue
+		}
+		if !j.isOrphaned(app) {
+			inUse = append(inUse, app.Name)
+			continue
+		}
+
+		clusters = append(clusters, &application.Cluster{
+			Name:         app.Name,
+			Provider:     provider,
+			Organization: org,
+		})
+	}
+
+	return clusters, inUse, nil
+}
+
+// deleteReleases removes all orphaned Releases whose Cluster no longer exists on the MC
+func (j *Janitor) deleteReleases(ctx context.Context, report *Report) error {
+	releaseList := &releases.ReleaseList{}
+	if err := j.framework.MC().List(ctx, releaseList, j.listOptions()...); err != nil {
+		return fmt.Errorf("failed to list releases: %w", err)
+	}
+
+	clusterList := &capi.ClusterList{}
+	if err := j.framework.MC().List(ctx, clusterList); err != nil {
+		return fmt.Errorf("failed to list clusters: %w", err)
+	}
+	existingClusters := map[string]bool{}
+	for _, cluster := range clusterList.Items {
+		existingClusters[cluster.Name] = true
+	}
+
+	for i := range releaseList.Items {
+		release := &releaseList.Items[i]
+		if !j.isOrphaned(release) {
+			continue
+		}
+		if clusterName := release.Labels["giantswarm.io/cluster"]; clusterName != "" && existingClusters[clusterName] {
+			// Still in use by an existing Cluster
+			continue
+		}
+
+		report.Releases = append(report.Releases, release.Name)
+
+		if j.options.DryRun {
+			logger.Log("[dry-run] Would delete Release '%s'", release.Name)
+			continue
+		}
+
+		logger.Log("Deleting orphaned Release '%s'", release.Name)
+		if err := j.framework.MC().Delete(ctx, release); cr.IgnoreNotFound(err) != nil {
+			report.Errors = append(report.Errors, fmt.Errorf("failed to delete release '%s': %w", release.Name, err))
+		}
+	}
+
+	return nil
+}
+
+// listOptions returns the list options needed to only select resources matching the label filters
+func (j *Janitor) listOptions() []cr.ListOption {
+	labels := cr.MatchingLabels{}
+	if j.options.PipelineRun != "" {
+		labels[PipelineRunLabel] = j.options.PipelineRun
+	}
+	if j.options.PR != "" {
+		labels[PRLabel] = j.options.PR
+	}
+	return []cr.ListOption{labels}
+}
+
+// isOrphaned checks if the provided resource is safe to delete, matches the label filters and is older than the max age
+func (j *Janitor) isOrphaned(obj metav1.Object) bool {
+	if !utils.SafeToDelete(obj.GetAnnotations()) {
+		return false
+	}
+
+	if j.options.PipelineRun != "" && obj.GetLabels()[PipelineRunLabel] != j.options.PipelineRun {
+		return false
+	}
+	if j.options.PR != "" && obj.GetLabels()[PRLabel] != j.options.PR {
+		return false
+	}
+
+	return time.Since(obj.GetCreationTimestamp().Time) >= j.options.MaxAge
+}
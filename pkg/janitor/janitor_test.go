@@ -0,0 +1,227 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	orgv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5"
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
+)
+
+func TestIsOrphaned(t *testing.T) {
+	old := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	recent := metav1.NewTime(time.Now().Add(-1 * time.Hour))
+	safeToDelete := map[string]string{utils.DeleteAnnotation: "true"}
+
+	testcases := []struct {
+		name     string
+		opts     []Option
+		obj      metav1.ObjectMeta
+		expected bool
+	}{
+		{"old and safe to delete", nil, metav1.ObjectMeta{CreationTimestamp: old, Annotations: safeToDelete}, true},
+		{"missing annotation", nil, metav1.ObjectMeta{CreationTimestamp: old}, false},
+		{"too recent", nil, metav1.ObjectMeta{CreationTimestamp: recent, Annotations: safeToDelete}, false},
+		{"custom max age", []Option{WithMaxAge(30 * time.Minute)}, metav1.ObjectMeta{CreationTimestamp: recent, Annotations: safeToDelete}, true},
+		{
+			"matching PR",
+			[]Option{WithPR("123")},
+			metav1.ObjectMeta{CreationTimestamp: old, Annotations: safeToDelete, Labels: map[string]string{PRLabel: "123"}},
+			true,
+		},
+		{
+			"different PR",
+			[]Option{WithPR("123")},
+			metav1.ObjectMeta{CreationTimestamp: old, Annotations: safeToDelete, Labels: map[string]string{PRLabel: "456"}},
+			false,
+		},
+		{
+			"matching pipeline run",
+			[]Option{WithPipelineRun("run-abc")},
+			metav1.ObjectMeta{CreationTimestamp: old, Annotations: safeToDelete, Labels: map[string]string{PipelineRunLabel: "run-abc"}},
+			true,
+		},
+		{
+			"missing pipeline run label",
+			[]Option{WithPipelineRun("run-abc")},
+			metav1.ObjectMeta{CreationTimestamp: old, Annotations: safeToDelete},
+			false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			j := New(nil, tc.opts...)
+			if actual := j.isOrphaned(&tc.obj); actual != tc.expected {
+				t.Errorf("isOrphaned not as expected. Expected: %t, Actual: %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+// fakeFramework records the deletions requested by the Janitor
+type fakeFramework struct {
+	mc    *client.Client
+	calls []string
+}
+
+func (f *fakeFramework) MC() *client.Client {
+	return f.mc
+}
+
+func (f *fakeFramework) DeleteCluster(ctx context.Context, cluster *application.Cluster, opts ...clustertest.DeleteOption) error {
+	options := &clustertest.DeleteOptions{}
+	for _, optFn := range opts {
+		optFn(options)
+	}
+	f.calls = append(f.calls, fmt.Sprintf("cluster %s/%s keepOrg=%t ignoreKeep=%t",
+		cluster.GetNamespace(), cluster.Name, options.KeepOrganization, options.IgnoreKeepWorkloadCluster))
+
+	return f.mc.Delete(ctx, &applicationv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: cluster.GetNamespace()}})
+}
+
+func (f *fakeFramework) DeleteOrg(_ context.Context, org *organization.Org) error {
+	f.calls = append(f.calls, fmt.Sprintf("org %s", org.Name))
+	return nil
+}
+
+func newTestFramework(t *testing.T) *fakeFramework {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		capi.AddToScheme,
+		applicationv1alpha1.AddToScheme,
+		orgv1alpha1.AddToScheme,
+		releases.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("Failed to build scheme - %v", err)
+		}
+	}
+
+	old := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	recent := metav1.NewTime(time.Now().Add(-1 * time.Hour))
+	orphaned := metav1.ObjectMeta{CreationTimestamp: old, Annotations: map[string]string{utils.DeleteAnnotation: "true"}}
+	inUse := metav1.ObjectMeta{CreationTimestamp: recent, Annotations: map[string]string{utils.DeleteAnnotation: "true"}}
+
+	withName := func(meta metav1.ObjectMeta, name string, namespace string) metav1.ObjectMeta {
+		meta.Name = name
+		meta.Namespace = namespace
+		return meta
+	}
+	clusterApp := func(meta metav1.ObjectMeta, appName string) *applicationv1alpha1.App {
+		return &applicationv1alpha1.App{ObjectMeta: meta, Spec: applicationv1alpha1.AppSpec{Name: appName}}
+	}
+
+	objects := []cr.Object{
+		// Orphaned Organization with multiple clusters
+		&orgv1alpha1.Organization{ObjectMeta: withName(orphaned, "orphaned", ""), Status: orgv1alpha1.OrganizationStatus{Namespace: "org-orphaned"}},
+		clusterApp(withName(orphaned, "t-one", "org-orphaned"), "cluster-aws"),
+		clusterApp(withName(orphaned, "t-two", "org-orphaned"), "cluster-aws"),
+		clusterApp(withName(metav1.ObjectMeta{}, "t-one-cilium", "org-orphaned"), "cilium"),
+		// Organization still in use with an orphaned cluster and one still in use
+		&orgv1alpha1.Organization{ObjectMeta: withName(inUse, "in-use", ""), Status: orgv1alpha1.OrganizationStatus{Namespace: "org-in-use"}},
+		clusterApp(withName(orphaned, "t-three", "org-in-use"), "cluster-azure"),
+		clusterApp(withName(inUse, "t-four", "org-in-use"), "cluster-azure"),
+		&capi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "t-four", Namespace: "org-in-use"}},
+		// Orphaned Organization reused by a cluster still in use
+		&orgv1alpha1.Organization{ObjectMeta: withName(orphaned, "reused", ""), Status: orgv1alpha1.OrganizationStatus{Namespace: "org-reused"}},
+		clusterApp(withName(orphaned, "t-five", "org-reused"), "cluster-aws"),
+		clusterApp(withName(inUse, "t-six", "org-reused"), "cluster-aws"),
+		// Releases
+		&releases.Release{ObjectMeta: withName(orphaned, "aws-30.0.0-one", "")},
+		&releases.Release{ObjectMeta: metav1.ObjectMeta{Name: "aws-30.0.0-four", CreationTimestamp: old,
+			Annotations: map[string]string{utils.DeleteAnnotation: "true"}, Labels: map[string]string{"giantswarm.io/cluster": "t-four"}}},
+		&releases.Release{ObjectMeta: withName(inUse, "aws-30.0.0-recent", "")},
+		&releases.Release{ObjectMeta: metav1.ObjectMeta{Name: "aws-30.0.0", CreationTimestamp: old}},
+	}
+
+	return &fakeFramework{
+		mc: &client.Client{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()},
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	framework := newTestFramework(t)
+
+	report, err := (&Janitor{framework: framework, options: &Options{MaxAge: DefaultMaxAge}}).Run(ctx)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	expectedCalls := []string{
+		"cluster org-orphaned/t-one keepOrg=true ignoreKeep=true",
+		"cluster org-orphaned/t-two keepOrg=true ignoreKeep=true",
+		"org orphaned",
+		"cluster org-in-use/t-three keepOrg=true ignoreKeep=true",
+		"cluster org-reused/t-five keepOrg=true ignoreKeep=true",
+	}
+	if !slices.Equal(framework.calls, expectedCalls) {
+		t.Errorf("Deletions not as expected.\nExpected: %v\nActual: %v", expectedCalls, framework.calls)
+	}
+
+	if !slices.Equal(report.Organizations, []string{"orphaned"}) {
+		t.Errorf("Reported organizations not as expected. Actual: %v", report.Organizations)
+	}
+	if !slices.Equal(report.SkippedOrganizations, []string{"reused"}) {
+		t.Errorf("Reported skipped organizations not as expected. Actual: %v", report.SkippedOrganizations)
+	}
+	if !slices.Equal(report.Clusters, []string{"org-orphaned/t-one", "org-orphaned/t-two", "org-in-use/t-three", "org-reused/t-five"}) {
+		t.Errorf("Reported clusters not as expected. Actual: %v", report.Clusters)
+	}
+	if !slices.Equal(report.Releases, []string{"aws-30.0.0-one"}) {
+		t.Errorf("Reported releases not as expected. Actual: %v", report.Releases)
+	}
+
+	releaseList := &releases.ReleaseList{}
+	if err := framework.MC().List(ctx, releaseList); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	remaining := []string{}
+	for _, release := range releaseList.Items {
+		remaining = append(remaining, release.Name)
+	}
+	if !slices.Equal(remaining, []string{"aws-30.0.0", "aws-30.0.0-four", "aws-30.0.0-recent"}) {
+		t.Errorf("Expected only the orphaned release to be pruned. Remaining: %v", remaining)
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	ctx := context.Background()
+	framework := newTestFramework(t)
+
+	report, err := (&Janitor{framework: framework, options: &Options{MaxAge: DefaultMaxAge, DryRun: true}}).Run(ctx)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if len(framework.calls) != 0 {
+		t.Errorf("Expected nothing to be deleted. Actual: %v", framework.calls)
+	}
+	if len(report.Organizations) != 1 || len(report.SkippedOrganizations) != 1 || len(report.Clusters) != 4 || len(report.Releases) != 1 {
+		t.Errorf("Expected all orphaned resources to be reported. Actual: %+v", report)
+	}
+
+	releaseList := &releases.ReleaseList{}
+	if err := framework.MC().List(ctx, releaseList); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(releaseList.Items) != 4 {
+		t.Errorf("Expected no releases to be deleted. Remaining: %d", len(releaseList.Items))
+	}
+}
@@ -1,6 +1,7 @@
 package organization
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -48,6 +49,36 @@ func (o *Org) GetNamespace() string {
 	return o.namespace
 }
 
+// orgJSON is the serialized form of an Org, including its namespace
+type orgJSON struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// MarshalJSON serializes the Org, including its namespace
+func (o Org) MarshalJSON() ([]byte, error) {
+	return json.Marshal(orgJSON{
+		Name:      o.Name,
+		Namespace: o.namespace,
+	})
+}
+
+// UnmarshalJSON deserializes an Org previously serialized with MarshalJSON.
+// If no namespace is included it defaults to `org-<name>`.
+func (o *Org) UnmarshalJSON(data []byte) error {
+	serialized := orgJSON{}
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return err
+	}
+
+	o.Name = serialized.Name
+	o.namespace = serialized.Namespace
+	if o.namespace == "" && o.Name != "" {
+		o.namespace = fmt.Sprintf("org-%s", o.Name)
+	}
+	return nil
+}
+
 // Build generates the Organization CR for applying to the cluster
 func (o *Org) Build() (*orgv1alpha1.Organization, error) {
 	orgCR, err := templateorg.NewOrganizationCR(templateorg.Config{
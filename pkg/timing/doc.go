@@ -0,0 +1,25 @@
+// package timing provides recording of how long each phase of a cluster lifecycle operation (such as applying or
+// deleting a cluster) took, along with exporting them as JSON or JUnit properties so provisioning times can be tracked
+// over time.
+//
+// A Recorder is used by the Framework to record the phases of `ApplyBuiltCluster` and `DeleteCluster` and is available
+// via [clustertest.Framework.PhaseTimings].
+//
+// # Example
+//
+//	// Apply and delete clusters...
+//
+//	file, err := os.Create("phase-timings.xml")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer file.Close()
+//
+//	err = framework.PhaseTimings().WriteJUnit(file)
+//
+// # Recording custom phases
+//
+//	err := framework.PhaseTimings().Time(cluster.Name, "upgrade", "control-plane", func() error {
+//		return waitForControlPlane(ctx)
+//	})
+package timing
@@ -0,0 +1,236 @@
+package timing
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// The cluster lifecycle operations recorded by the Framework
+const (
+	OperationApply  = "apply"
+	OperationDelete = "delete"
+)
+
+// The phases recorded by the Framework when applying a cluster
+const (
+	PhaseRelease      = "release"
+	PhaseOrganization = "organization"
+	PhaseClusterApp   = "cluster-app"
+	PhaseKubeconfig   = "kubeconfig"
+	PhaseAPIReachable = "api-reachable"
+	PhaseTestUser     = "test-user"
+)
+
+// The phases recorded by the Framework when deleting a cluster
+const (
+	PhaseClusterAppDeletion   = "cluster-app-deletion"
+	PhaseClusterDeletion      = "cluster-deletion"
+	PhaseBastionSecretCleanup = "bastion-secret-cleanup"
+	PhaseReleaseDeletion      = "release-deletion"
+	PhaseOrganizationDeletion = "organization-deletion"
+)
+
+// PhaseTotal is recorded with the overall duration of an operation
+const PhaseTotal = "total"
+
+// Phase is the recorded duration of a single phase of a cluster lifecycle operation
+type Phase struct {
+	Cluster   string
+	Operation string
+	Name      string
+	StartedAt time.Time
+	Duration  time.Duration
+	// Error is the error message of the phase if it failed
+	Error string
+}
+
+// phaseJSON is the serialized form of a Phase
+type phaseJSON struct {
+	Cluster         string    `json:"cluster"`
+	Operation       string    `json:"operation"`
+	Phase           string    `json:"phase"`
+	StartedAt       time.Time `json:"startedAt"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// Recorder records the phases of cluster lifecycle operations. It is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	phases []Phase
+
+	now func() time.Time
+}
+
+// NewRecorder returns a new, empty, Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		now: time.Now,
+	}
+}
+
+// Time runs the provided function, recording how long it took as the given phase of the operation on the cluster.
+// The error returned by the function is recorded with the phase and returned as is.
+func (r *Recorder) Time(cluster string, operation string, phase string, fn func() error) error {
+	start := r.now()
+	err := fn()
+
+	recorded := Phase{
+		Cluster:   cluster,
+		Operation: operation,
+		Name:      phase,
+		StartedAt: start,
+		Duration:  r.now().Sub(start),
+	}
+	if err != nil {
+		recorded.Error = err.Error()
+	}
+	r.Record(recorded)
+
+	return err
+}
+
+// Record adds an already measured Phase
+func (r *Recorder) Record(phase Phase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases = append(r.phases, phase)
+}
+
+// Phases returns all the recorded phases in the order they were recorded
+func (r *Recorder) Phases() []Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Phase{}, r.phases...)
+}
+
+// Reset removes all recorded phases
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases = nil
+}
+
+// WriteJSON writes all recorded phases as a JSON array, with each phase in the format:
+//
+//	{"cluster": "t-abc", "operation": "apply", "phase": "kubeconfig", "startedAt": "...", "durationSeconds": 12.3}
+func (r *Recorder) WriteJSON(w io.Writer) error {
+	phases := []phaseJSON{}
+	for _, phase := range r.Phases() {
+		phases = append(phases, phaseJSON{
+			Cluster:         phase.Cluster,
+			Operation:       phase.Operation,
+			Phase:           phase.Name,
+			StartedAt:       phase.StartedAt,
+			DurationSeconds: phase.Duration.Seconds(),
+			Error:           phase.Error,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(phases)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes all recorded phases as a JUnit XML report. Each operation on a cluster is a test suite with the
+// duration, in seconds, of each of its phases as a property (e.g. `kubeconfig.seconds`) and as a test case.
+func (r *Recorder) WriteJUnit(w io.Writer) error {
+	report := junitTestSuites{}
+	suiteIndex := map[string]int{}
+	suiteDurations := map[int]time.Duration{}
+
+	for _, phase := range r.Phases() {
+		suiteName := fmt.Sprintf("%s %s", phase.Operation, phase.Cluster)
+		i, ok := suiteIndex[suiteName]
+		if !ok {
+			report.Suites = append(report.Suites, junitTestSuite{
+				Name:      suiteName,
+				Timestamp: phase.StartedAt.UTC().Format(time.RFC3339),
+				Properties: []junitProperty{
+					{Name: "cluster", Value: phase.Cluster},
+					{Name: "operation", Value: phase.Operation},
+				},
+			})
+			i = len(report.Suites) - 1
+			suiteIndex[suiteName] = i
+		}
+
+		suite := &report.Suites[i]
+		seconds := formatSeconds(phase.Duration)
+		suite.Properties = append(suite.Properties, junitProperty{Name: fmt.Sprintf("%s.seconds", phase.Name), Value: seconds})
+
+		testCase := junitTestCase{
+			Name:      phase.Name,
+			ClassName: fmt.Sprintf("clustertest.%s", phase.Operation),
+			Time:      seconds,
+		}
+		if phase.Error != "" {
+			testCase.Failure = &junitFailure{Message: phase.Error}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+
+		if phase.Name == PhaseTotal {
+			suite.Time = seconds
+		} else {
+			suiteDurations[i] += phase.Duration
+		}
+	}
+	for i := range report.Suites {
+		// Fall back to the sum of all phases if the overall duration wasn't recorded
+		if report.Suites[i].Time == "" {
+			report.Suites[i].Time = formatSeconds(suiteDurations[i])
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,103 @@
+package timing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRecorder returns a Recorder where each phase takes exactly the provided step duration
+func newTestRecorder(step time.Duration) *Recorder {
+	current := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRecorder()
+	r.now = func() time.Time {
+		now := current
+		current = current.Add(step / 2)
+		return now
+	}
+	return r
+}
+
+func TestTime(t *testing.T) {
+	r := newTestRecorder(2 * time.Second)
+
+	err := r.Time("example", OperationApply, PhaseRelease, func() error { return nil })
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	expectedErr := fmt.Errorf("timed out")
+	err = r.Time("example", OperationApply, PhaseKubeconfig, func() error { return expectedErr })
+	if err != expectedErr {
+		t.Errorf("Expected the phase error to be returned. Actual: %v", err)
+	}
+
+	phases := r.Phases()
+	if len(phases) != 2 {
+		t.Fatalf("Expected 2 phases to be recorded. Actual: %d", len(phases))
+	}
+	if phases[0].Name != PhaseRelease || phases[0].Duration != 1*time.Second || phases[0].Error != "" {
+		t.Errorf("First phase not as expected. Actual: %+v", phases[0])
+	}
+	if phases[1].Name != PhaseKubeconfig || phases[1].Error != "timed out" {
+		t.Errorf("Second phase not as expected. Actual: %+v", phases[1])
+	}
+
+	r.Reset()
+	if len(r.Phases()) != 0 {
+		t.Errorf("Expected no phases after reset. Actual: %d", len(r.Phases()))
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	r := newTestRecorder(3 * time.Second)
+	_ = r.Time("example", OperationApply, PhaseRelease, func() error { return nil })
+
+	buf := &bytes.Buffer{}
+	if err := r.WriteJSON(buf); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	actual := []map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+		t.Fatalf("Failed to parse JSON - %v", err)
+	}
+	if len(actual) != 1 {
+		t.Fatalf("Expected 1 phase. Actual: %d", len(actual))
+	}
+	if actual[0]["cluster"] != "example" || actual[0]["operation"] != OperationApply || actual[0]["phase"] != PhaseRelease {
+		t.Errorf("Phase not as expected. Actual: %v", actual[0])
+	}
+	if actual[0]["durationSeconds"] != 1.5 {
+		t.Errorf("Duration not as expected. Expected: 1.5, Actual: %v", actual[0]["durationSeconds"])
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	r := newTestRecorder(2 * time.Second)
+	_ = r.Time("example", OperationApply, PhaseRelease, func() error { return nil })
+	_ = r.Time("example", OperationApply, PhaseKubeconfig, func() error { return fmt.Errorf("timed out") })
+	_ = r.Time("example", OperationDelete, PhaseClusterDeletion, func() error { return nil })
+
+	buf := &bytes.Buffer{}
+	if err := r.WriteJUnit(buf); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	actual := buf.String()
+
+	for _, expected := range []string{
+		`<testsuite name="apply example" tests="2" failures="1" time="2.000"`,
+		`<property name="release.seconds" value="1.000"></property>`,
+		`<property name="kubeconfig.seconds" value="1.000"></property>`,
+		`<failure message="timed out"></failure>`,
+		`<testsuite name="delete example" tests="1" failures="0" time="1.000"`,
+		`<property name="cluster-deletion.seconds" value="1.000"></property>`,
+	} {
+		if !strings.Contains(actual, expected) {
+			t.Errorf("Expected JUnit report to contain %s. Actual:\n%s", expected, actual)
+		}
+	}
+}
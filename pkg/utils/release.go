@@ -2,15 +2,10 @@ package utils
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
-	"time"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/cenkalti/backoff/v7"
 
 	"github.com/giantswarm/clustertest/v5/pkg/env"
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
@@ -33,6 +28,7 @@ type Release struct {
 // the previous major version.
 //
 // A `provider` must be provided so that the correct releases can be looked up from `giantswarm/releases`.
+// Releases are looked up using the VersionResolver returned by `GetVersionResolver`.
 func GetUpgradeReleasesToTest(provider string) (from string, to string, err error) {
 	to = os.Getenv(env.ReleaseVersion)
 	from = os.Getenv(env.ReleasePreUpgradeVersion)
@@ -51,47 +47,10 @@ func GetUpgradeReleasesToTest(provider string) (from string, to string, err erro
 		return "", "", fmt.Errorf("failed to parse release version to test '%s': %w", to, err)
 	}
 
-	// We need to find the latest release from the previous major
-	releasesURL := fmt.Sprintf("https://raw.githubusercontent.com/giantswarm/releases/master/%s/releases.json", provider)
-
-	bo := backoff.NewExponentialBackOff()
-	bo.InitialInterval = 1 * time.Second
-	bo.MaxInterval = 15 * time.Second
-	bo.RandomizationFactor = 0.1 // Add some jitter
-
-	operation := func() ([]byte, error) {
-		resp, err := http.Get(releasesURL) // #nosec G107
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to fetch releases file, status code %d", resp.StatusCode)
-		}
-
-		return io.ReadAll(resp.Body)
-	}
-
-	notify := func(err error, d time.Duration) {
-		logger.Log("Failed to fetch releases file: %s. Retrying in %s...", err, d.Round(time.Second))
-	}
-
-	body, err := backoff.Retry(
-		context.Background(),
-		operation,
-		backoff.WithBackOff(bo),
-		backoff.WithMaxElapsedTime(1*time.Minute),
-		backoff.WithNotify(notify),
-	)
-
+	// Look up all the published releases for the provider to pick the 'from' release
+	releases, err := GetVersionResolver().Releases(context.Background(), provider)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to fetch releases file from '%s' after multiple retries: %w", releasesURL, err)
-	}
-
-	var releasesFile ReleasesFile
-	if err := json.Unmarshal(body, &releasesFile); err != nil {
-		return "", "", fmt.Errorf("failed to unmarshal releases.json from '%s': %w", releasesURL, err)
+		return "", "", err
 	}
 
 	if from == "previous_major" {
@@ -99,7 +58,7 @@ func GetUpgradeReleasesToTest(provider string) (from string, to string, err erro
 
 		previousMajor := toVersion.Major() - 1
 		var latestPreviousMajorRelease *semver.Version
-		for _, release := range releasesFile.Releases {
+		for _, release := range releases {
 			versionStr, err := semver.NewVersion(release.Version)
 			if err != nil {
 				// We'll ignore releases we can't parse
@@ -124,7 +83,7 @@ func GetUpgradeReleasesToTest(provider string) (from string, to string, err erro
 
 		previousMajor := toVersion.Major() - 1
 		var earliestPreviousMajorRelease *semver.Version
-		for _, release := range releasesFile.Releases {
+		for _, release := range releases {
 			if release.IsDeprecated {
 				continue
 			}
@@ -152,7 +111,7 @@ func GetUpgradeReleasesToTest(provider string) (from string, to string, err erro
 		logger.Log("Predecessor release not set. Auto-detecting latest release...")
 
 		var latestPreviousRelease *semver.Version
-		for _, release := range releasesFile.Releases {
+		for _, release := range releases {
 			version, err := semver.NewVersion(release.Version)
 			if err != nil {
 				continue
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// DefaultVersionsCacheTTL is how long cached versions are used for if `E2E_VERSIONS_CACHE_TTL` isn't set
+const DefaultVersionsCacheTTL = 1 * time.Hour
+
+// VersionResolver looks up the versions of apps and releases available to test against.
+//
+// The resolver in use can be changed with `SetVersionResolver`, otherwise one is chosen based on the environment
+// (see `GetVersionResolver`).
+type VersionResolver interface {
+	// LatestAppVersion returns the latest stable version (tag) of the app with the given repo name
+	LatestAppVersion(ctx context.Context, repoName string) (string, error)
+	// Releases returns all the releases published for the given provider
+	Releases(ctx context.Context, provider string) ([]Release, error)
+}
+
+var (
+	versionResolverMu sync.Mutex
+	versionResolver   VersionResolver
+)
+
+// SetVersionResolver overrides the VersionResolver used when looking up app and release versions, such as when
+// building an Application with no version set or by `GetUpgradeReleasesToTest`.
+// Providing nil resets it back to the resolver chosen from the environment.
+//
+// Example:
+//
+//	utils.SetVersionResolver(utils.NewOfflineVersionResolver("./testdata/versions.yaml"))
+func SetVersionResolver(resolver VersionResolver) {
+	versionResolverMu.Lock()
+	defer versionResolverMu.Unlock()
+	versionResolver = resolver
+}
+
+// GetVersionResolver returns the VersionResolver currently in use.
+//
+// If one hasn't been set with `SetVersionResolver` it is chosen based on the following env vars:
+//   - `E2E_VERSIONS_FILE` - all versions are read from the given local file and the network is never used
+//   - `E2E_VERSIONS_CACHE_DIR` - versions are fetched from GitHub and cached on disk in the given directory
+//     for the duration set in `E2E_VERSIONS_CACHE_TTL` (defaults to 1h)
+//
+// Otherwise, versions are always fetched from GitHub.
+func GetVersionResolver() VersionResolver {
+	versionResolverMu.Lock()
+	defer versionResolverMu.Unlock()
+
+	if versionResolver == nil {
+		versionResolver = versionResolverFromEnv()
+	}
+	return versionResolver
+}
+
+func versionResolverFromEnv() VersionResolver {
+	if versionsFile := os.Getenv(env.VersionsFile); versionsFile != "" {
+		logger.Log("Using offline versions from '%s'", versionsFile)
+		return NewOfflineVersionResolver(versionsFile)
+	}
+
+	resolver := VersionResolver(NewGitHubVersionResolver())
+
+	if cacheDir := os.Getenv(env.VersionsCacheDir); cacheDir != "" {
+		ttl := DefaultVersionsCacheTTL
+		if ttlValue := os.Getenv(env.VersionsCacheTTL); ttlValue != "" {
+			parsed, err := time.ParseDuration(ttlValue)
+			if err != nil {
+				logger.Log("Invalid %s value '%s', using default of %s - %v", env.VersionsCacheTTL, ttlValue, ttl, err)
+			} else {
+				ttl = parsed
+			}
+		}
+		resolver = NewCachedVersionResolver(resolver, cacheDir, ttl)
+	}
+
+	return resolver
+}
+
+// IsOffline returns true if the VersionResolver in use is an OfflineVersionResolver, meaning the network must not be
+// used to look up versions or releases
+func IsOffline() bool {
+	_, ok := GetVersionResolver().(*OfflineVersionResolver)
+	return ok
+}
+
+// appRepoVariations returns the provided app repo name along with the variation with or without the `-app` suffix
+func appRepoVariations(repoName string) []string {
+	if trimmed, ok := strings.CutSuffix(repoName, "-app"); ok {
+		return []string{repoName, trimmed}
+	}
+	return []string{repoName, repoName + "-app"}
+}
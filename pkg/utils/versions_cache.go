@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// CachedVersionResolver is a VersionResolver that caches the results of another VersionResolver on disk.
+//
+// Cache entries are keyed by app repo name (or provider for releases) and are used until they are older than the
+// configured TTL. A TTL of zero or less means cached entries never expire.
+type CachedVersionResolver struct {
+	resolver VersionResolver
+	dir      string
+	ttl      time.Duration
+}
+
+// versionsCacheEntry is the on-disk format of a single cached lookup
+type versionsCacheEntry struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Version   string    `json:"version,omitempty"`
+	Releases  []Release `json:"releases,omitempty"`
+}
+
+// NewCachedVersionResolver returns a new CachedVersionResolver that caches the results of the given resolver in `dir`
+//
+// Example:
+//
+//	resolver := utils.NewCachedVersionResolver(utils.NewGitHubVersionResolver(), "/tmp/versions-cache", 6*time.Hour)
+//	utils.SetVersionResolver(resolver)
+func NewCachedVersionResolver(resolver VersionResolver, dir string, ttl time.Duration) *CachedVersionResolver {
+	return &CachedVersionResolver{
+		resolver: resolver,
+		dir:      dir,
+		ttl:      ttl,
+	}
+}
+
+// LatestAppVersion returns the cached latest version of the app if available and not expired, otherwise it is looked
+// up with the underlying resolver and cached
+func (r *CachedVersionResolver) LatestAppVersion(ctx context.Context, repoName string) (string, error) {
+	cachePath := r.cachePath("apps", repoName)
+	if entry, ok := r.read(cachePath); ok && entry.Version != "" {
+		return entry.Version, nil
+	}
+
+	version, err := r.resolver.LatestAppVersion(ctx, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	r.write(cachePath, versionsCacheEntry{FetchedAt: time.Now(), Version: version})
+	return version, nil
+}
+
+// Releases returns the cached releases of the provider if available and not expired, otherwise they are looked up
+// with the underlying resolver and cached
+func (r *CachedVersionResolver) Releases(ctx context.Context, provider string) ([]Release, error) {
+	cachePath := r.cachePath("releases", provider)
+	if entry, ok := r.read(cachePath); ok && entry.Releases != nil {
+		return entry.Releases, nil
+	}
+
+	releases, err := r.resolver.Releases(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	r.write(cachePath, versionsCacheEntry{FetchedAt: time.Now(), Releases: releases})
+	return releases, nil
+}
+
+func (r *CachedVersionResolver) cachePath(kind string, key string) string {
+	safeKey := strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(key)
+	return filepath.Join(r.dir, kind, fmt.Sprintf("%s.json", safeKey))
+}
+
+// read returns the cache entry at the given path if it exists and hasn't expired
+func (r *CachedVersionResolver) read(cachePath string) (versionsCacheEntry, bool) {
+	entry := versionsCacheEntry{}
+
+	data, err := os.ReadFile(cachePath) // #nosec G304
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		logger.Log("Ignoring invalid versions cache file '%s' - %v", cachePath, err)
+		return entry, false
+	}
+	if r.ttl > 0 && time.Since(entry.FetchedAt) > r.ttl {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// write stores the cache entry at the given path. Failures are logged but otherwise ignored as the cache is only an
+// optimisation.
+func (r *CachedVersionResolver) write(cachePath string, entry versionsCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		logger.Log("Failed to marshal versions cache entry - %v", err)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o750); err != nil {
+		logger.Log("Failed to create versions cache directory - %v", err)
+		return
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partially written entry
+	tmpFile, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*")
+	if err != nil {
+		logger.Log("Failed to write versions cache file '%s' - %v", cachePath, err)
+		return
+	}
+	_, err = tmpFile.Write(data)
+	closeErr := tmpFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), cachePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		logger.Log("Failed to write versions cache file '%s' - %v", cachePath, err)
+	}
+}
@@ -0,0 +1,244 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/cenkalti/backoff/v7"
+	"github.com/google/go-github/v90/github"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// GitHubVersionResolver is a VersionResolver that looks up versions from GitHub on every call
+type GitHubVersionResolver struct {
+	token string
+}
+
+// NewGitHubVersionResolver returns a new GitHubVersionResolver using the GitHub token found in the environment (see
+// `GetGitHubToken`)
+func NewGitHubVersionResolver() *GitHubVersionResolver {
+	return &GitHubVersionResolver{
+		token: GetGitHubToken(),
+	}
+}
+
+// newGitHubClient returns a new initialized GitHub client using the resolver's GitHub token
+func (r *GitHubVersionResolver) newGitHubClient() (*github.Client, error) {
+	var opts []github.ClientOptionsFunc
+	if r.token != "" {
+		opts = append(opts, github.WithAuthToken(r.token))
+	}
+	return github.NewClient(opts...)
+}
+
+// LatestAppVersion returns the latest version (tag) name for a given repos release.
+//
+// The latest version is determined by semantic versioning, not by the most recently created release.
+// This ensures that patch releases on older major versions (e.g., v5.4.0) don't override newer versions (e.g., v6.4.x).
+//
+// Repos are checked both with and without the `-app` suffix of the provided `repoName`.
+// The provided `repoName` is used as preference when looking up releases but if fails will fallback to the
+// suffix variation.
+//
+// The function includes retry logic with exponential backoff to handle transient network issues or GitHub API rate limiting.
+// It will give up after a maximum of 1 minute.
+func (r *GitHubVersionResolver) LatestAppVersion(ctx context.Context, repoName string) (string, error) {
+	gh, err := r.newGitHubClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	operation := func() (string, error) {
+		var lastErr error
+		for _, appName := range appRepoVariations(repoName) {
+			version, err := getLatestSemverRelease(ctx, gh, appName)
+			if err == nil {
+				return version, nil
+			}
+
+			lastErr = err
+
+			// Only retry on specific HTTP status codes that indicate transient issues
+			if isTransientGitHubError(err) {
+				return "", err
+			}
+		}
+		return "", backoff.Permanent(lastErr)
+	}
+
+	notify := func(err error, d time.Duration) {
+		logger.Log("Failed to get latest app version: %s. Retrying in %s...", err, d.Round(time.Second))
+	}
+
+	version, err := backoff.Retry(
+		ctx,
+		operation,
+		backoff.WithBackOff(newGitHubBackOff()),
+		backoff.WithMaxElapsedTime(1*time.Minute),
+		backoff.WithNotify(notify),
+	)
+
+	if err != nil {
+		return "", fmt.Errorf("unable to get latest release of %s: %v", repoName, err)
+	}
+
+	if version == "" {
+		return "", fmt.Errorf("unable to get latest release of %s: no release found", repoName)
+	}
+
+	return version, nil
+}
+
+// Releases fetches the `releases.json` file for the given provider from the `giantswarm/releases` repo.
+//
+// The function includes retry logic with exponential backoff and will give up after a maximum of 1 minute.
+func (r *GitHubVersionResolver) Releases(ctx context.Context, provider string) ([]Release, error) {
+	releasesURL := fmt.Sprintf("https://raw.githubusercontent.com/giantswarm/releases/master/%s/releases.json", provider)
+
+	operation := func() ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+		if err != nil {
+			return nil, backoff.Permanent(err)
+		}
+		resp, err := http.DefaultClient.Do(req) // #nosec G107 G704
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch releases file, status code %d", resp.StatusCode)
+		}
+
+		return io.ReadAll(resp.Body)
+	}
+
+	notify := func(err error, d time.Duration) {
+		logger.Log("Failed to fetch releases file: %s. Retrying in %s...", err, d.Round(time.Second))
+	}
+
+	body, err := backoff.Retry(
+		ctx,
+		operation,
+		backoff.WithBackOff(newGitHubBackOff()),
+		backoff.WithMaxElapsedTime(1*time.Minute),
+		backoff.WithNotify(notify),
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases file from '%s' after multiple retries: %w", releasesURL, err)
+	}
+
+	var releasesFile ReleasesFile
+	if err := json.Unmarshal(body, &releasesFile); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal releases.json from '%s': %w", releasesURL, err)
+	}
+
+	return releasesFile.Releases, nil
+}
+
+func newGitHubBackOff() *backoff.ExponentialBackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 1 * time.Second
+	bo.MaxInterval = 15 * time.Second
+	bo.RandomizationFactor = 0.1 // Add some jitter
+	return bo
+}
+
+// getLatestSemverRelease fetches all releases for a repository and returns the tag name
+// of the release with the highest semantic version (excluding pre-releases and drafts).
+func getLatestSemverRelease(ctx context.Context, gh *github.Client, repoName string) (string, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var allReleases []*github.RepositoryRelease
+	for {
+		releases, resp, err := gh.Repositories.ListReleases(ctx, "giantswarm", repoName, opts)
+		if err != nil {
+			return "", err
+		}
+		allReleases = append(allReleases, releases...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if len(allReleases) == 0 {
+		return "", fmt.Errorf("no releases found")
+	}
+
+	// Filter to only stable releases (non-draft, non-prerelease) and parse their versions
+	type releaseVersion struct {
+		tagName string
+		version *semver.Version
+	}
+	var stableReleases []releaseVersion
+
+	for _, release := range allReleases {
+		if release.GetDraft() || release.GetPrerelease() {
+			continue
+		}
+		tagName := release.GetTagName()
+		if tagName == "" {
+			continue
+		}
+		v, err := semver.NewVersion(tagName)
+		if err != nil {
+			// Skip releases with non-semver tags
+			continue
+		}
+		// Also skip pre-release versions (e.g., v1.0.0-alpha) even if not marked as prerelease
+		if v.Prerelease() != "" {
+			continue
+		}
+		stableReleases = append(stableReleases, releaseVersion{tagName: tagName, version: v})
+	}
+
+	if len(stableReleases) == 0 {
+		return "", fmt.Errorf("no stable releases found")
+	}
+
+	// Sort by version descending to get the highest version first
+	slices.SortFunc(stableReleases, func(a, b releaseVersion) int {
+		return b.version.Compare(a.version) // Descending order
+	})
+
+	return stableReleases[0].tagName, nil
+}
+
+// isTransientGitHubError determines if a GitHub API error is likely transient and should be retried
+// Only checks HTTP status codes - no string matching
+func isTransientGitHubError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Only retry on specific HTTP status codes that indicate transient server-side issues
+	if ghErr, ok := err.(*github.ErrorResponse); ok {
+		switch ghErr.Response.StatusCode {
+		case http.StatusTooManyRequests: // 429
+			return true
+		case http.StatusInternalServerError: // 500
+			return true
+		case http.StatusBadGateway: // 502
+			return true
+		case http.StatusServiceUnavailable: // 503
+			return true
+		case http.StatusGatewayTimeout: // 504
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OfflineVersionResolver is a VersionResolver that reads all versions from a local YAML (or JSON) file and never
+// uses the network. This allows for reproducible builds and unit tests that don't need network access.
+//
+// The file has the following format:
+//
+//	apps:
+//	  cluster-aws: v2.3.0
+//	  cilium-app: v0.25.1
+//	releases:
+//	  aws:
+//	    - version: 29.1.0
+//	    - version: 30.0.0
+//	      isDeprecated: false
+//
+// Only version lookups go through the resolver. Building the full Release CR of a cluster (see
+// `application.Cluster.GetRelease`) requires the Release manifests from GitHub so it returns an error, instead of
+// using the network, while this resolver is in use (see `IsOffline`).
+type OfflineVersionResolver struct {
+	path string
+}
+
+// OfflineVersions is the content of the file used by an OfflineVersionResolver
+type OfflineVersions struct {
+	Apps     map[string]string    `json:"apps"`
+	Releases map[string][]Release `json:"releases"`
+}
+
+// NewOfflineVersionResolver returns a new OfflineVersionResolver reading versions from the file at the given path.
+// The file is read on each lookup.
+func NewOfflineVersionResolver(path string) *OfflineVersionResolver {
+	return &OfflineVersionResolver{
+		path: path,
+	}
+}
+
+// LatestAppVersion returns the version of the app from the versions file. Both the provided repo name and the variation
+// with or without the `-app` suffix are checked.
+func (r *OfflineVersionResolver) LatestAppVersion(_ context.Context, repoName string) (string, error) {
+	versions, err := r.load()
+	if err != nil {
+		return "", err
+	}
+
+	for _, appName := range appRepoVariations(repoName) {
+		if version, ok := versions.Apps[appName]; ok && version != "" {
+			return version, nil
+		}
+	}
+
+	return "", fmt.Errorf("no version found for app '%s' in offline versions file '%s'", repoName, r.path)
+}
+
+// Releases returns the releases of the provider from the versions file
+func (r *OfflineVersionResolver) Releases(_ context.Context, provider string) ([]Release, error) {
+	versions, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	releases, ok := versions.Releases[provider]
+	if !ok {
+		return nil, fmt.Errorf("no releases found for provider '%s' in offline versions file '%s'", provider, r.path)
+	}
+
+	return releases, nil
+}
+
+func (r *OfflineVersionResolver) load() (*OfflineVersions, error) {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offline versions file '%s': %w", r.path, err)
+	}
+
+	versions := &OfflineVersions{}
+	if err := yaml.Unmarshal(data, versions); err != nil {
+		return nil, fmt.Errorf("failed to parse offline versions file '%s': %w", r.path, err)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+)
+
+type fakeVersionResolver struct {
+	calls    int
+	version  string
+	releases []Release
+}
+
+func (r *fakeVersionResolver) LatestAppVersion(_ context.Context, _ string) (string, error) {
+	r.calls++
+	return r.version, nil
+}
+
+func (r *fakeVersionResolver) Releases(_ context.Context, _ string) ([]Release, error) {
+	r.calls++
+	return r.releases, nil
+}
+
+func TestCachedVersionResolver(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeVersionResolver{version: "v1.0.0", releases: []Release{{Version: "30.0.0"}}}
+	dir := t.TempDir()
+
+	resolver := NewCachedVersionResolver(fake, dir, time.Hour)
+	for range 3 {
+		version, err := resolver.LatestAppVersion(ctx, "cluster-aws")
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+		if version != "v1.0.0" {
+			t.Errorf("Version not as expected. Expected: v1.0.0, Actual: %s", version)
+		}
+	}
+	if fake.calls != 1 {
+		t.Errorf("Expected the underlying resolver to be called once, Actual: %d", fake.calls)
+	}
+
+	releases, err := resolver.Releases(ctx, "aws")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(releases) != 1 || releases[0].Version != "30.0.0" {
+		t.Errorf("Releases not as expected. Actual: %v", releases)
+	}
+	if fake.calls != 2 {
+		t.Errorf("Expected releases to be fetched separately from app versions, Actual calls: %d", fake.calls)
+	}
+
+	// A new resolver using the same directory should use the entries cached on disk
+	fake.version = "v2.0.0"
+	version, err := NewCachedVersionResolver(fake, dir, time.Hour).LatestAppVersion(ctx, "cluster-aws")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if version != "v1.0.0" || fake.calls != 2 {
+		t.Errorf("Expected cached version v1.0.0 without calling the underlying resolver. Actual: %s (calls: %d)", version, fake.calls)
+	}
+
+	// Expired entries are looked up again
+	version, err = NewCachedVersionResolver(fake, dir, time.Nanosecond).LatestAppVersion(ctx, "cluster-aws")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if version != "v2.0.0" || fake.calls != 3 {
+		t.Errorf("Expected expired entry to be refreshed to v2.0.0. Actual: %s (calls: %d)", version, fake.calls)
+	}
+}
+
+func TestOfflineVersionResolver(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewOfflineVersionResolver(path.Join("test_data", "versions.yaml"))
+
+	testCases := []struct {
+		repoName        string
+		expectedVersion string
+		expectError     bool
+	}{
+		{"cluster-aws", "v2.3.0", false},
+		{"cluster-aws-app", "v2.3.0", false},
+		{"cilium", "v0.25.1", false},
+		{"unknown", "", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.repoName, func(t *testing.T) {
+			version, err := resolver.LatestAppVersion(ctx, tc.repoName)
+			if tc.expectError && err == nil {
+				t.Errorf("Was expecting an error to be returned")
+			} else if !tc.expectError && err != nil {
+				t.Errorf("Not expecting an error to be returned - %v", err)
+			}
+			if version != tc.expectedVersion {
+				t.Errorf("Version not as expected. Expected: %s, Actual: %s", tc.expectedVersion, version)
+			}
+		})
+	}
+
+	releases, err := resolver.Releases(ctx, "aws")
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if len(releases) != 4 {
+		t.Errorf("Expected 4 releases, Actual: %d", len(releases))
+	}
+
+	if _, err := resolver.Releases(ctx, "azure"); err == nil {
+		t.Errorf("Was expecting an error for an unknown provider")
+	}
+}
+
+func TestGetUpgradeReleasesToTest_Offline(t *testing.T) {
+	SetVersionResolver(NewOfflineVersionResolver(path.Join("test_data", "versions.yaml")))
+	defer SetVersionResolver(nil)
+
+	testCases := []struct {
+		preUpgrade   string
+		expectedFrom string
+	}{
+		{"", "30.0.0"},
+		{"previous_major", "29.2.0"},
+		{"first_previous_major", "29.1.0"},
+		{"28.0.0", "28.0.0"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.preUpgrade, func(t *testing.T) {
+			t.Setenv(env.ReleaseVersion, "30.1.0")
+			t.Setenv(env.ReleasePreUpgradeVersion, tc.preUpgrade)
+
+			from, to, err := GetUpgradeReleasesToTest("aws")
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if from != tc.expectedFrom || to != "30.1.0" {
+				t.Errorf("Releases not as expected. Expected: %s -> 30.1.0, Actual: %s -> %s", tc.expectedFrom, from, to)
+			}
+		})
+	}
+}
+
+func TestGetVersionResolver_FromEnv(t *testing.T) {
+	SetVersionResolver(nil)
+	defer SetVersionResolver(nil)
+
+	t.Setenv(env.VersionsFile, path.Join("test_data", "versions.yaml"))
+	if _, ok := GetVersionResolver().(*OfflineVersionResolver); !ok {
+		t.Errorf("Expected an OfflineVersionResolver when %s is set", env.VersionsFile)
+	}
+	if !IsOffline() {
+		t.Errorf("Expected to be offline when %s is set", env.VersionsFile)
+	}
+
+	SetVersionResolver(nil)
+	os.Unsetenv(env.VersionsFile) //nolint:errcheck
+	t.Setenv(env.VersionsCacheDir, t.TempDir())
+	if _, ok := GetVersionResolver().(*CachedVersionResolver); !ok {
+		t.Errorf("Expected a CachedVersionResolver when %s is set", env.VersionsCacheDir)
+	}
+	if IsOffline() {
+		t.Errorf("Expected to not be offline when %s is set", env.VersionsCacheDir)
+	}
+}
@@ -0,0 +1,232 @@
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// All returns a WaitCondition that is done once all of the provided WaitConditions are done.
+// The conditions are checked in order, stopping at the first one not yet done or that returns an error.
+func All(conditions ...WaitCondition) WaitCondition {
+	return func() (bool, error) {
+		for _, condition := range conditions {
+			done, err := condition()
+			if err != nil || !done {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+}
+
+// Any returns a WaitCondition that is done as soon as any of the provided WaitConditions is done.
+// The conditions are checked in order. Errors are treated as the condition not being met (and logged) so that
+// alternatives that can't yet be checked, e.g. because the resource doesn't exist, don't stop the waiting.
+//
+// Example:
+//
+//	wait.Any(
+//		wait.IsAppDeployed(ctx, kubeClient, name, namespace),
+//		helmrelease.IsHelmReleaseReady(ctx, kubeClient, name, namespace),
+//	)
+func Any(conditions ...WaitCondition) WaitCondition {
+	return func() (bool, error) {
+		for _, condition := range conditions {
+			done, err := condition()
+			if err != nil {
+				logger.Log("Condition not met - %v", err)
+				continue
+			}
+			if done {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// Not returns a WaitCondition that is done when the provided WaitCondition isn't done. Errors are returned as-is.
+func Not(condition WaitCondition) WaitCondition {
+	return func() (bool, error) {
+		done, err := condition()
+		if err != nil {
+			return false, err
+		}
+		return !done, nil
+	}
+}
+
+// Sequence returns a WaitCondition that requires the provided WaitConditions to be done in order. Once a step is done
+// it isn't checked again and the next step is checked straight away. The returned WaitCondition is done once all the
+// steps have been done.
+//
+// The returned WaitCondition keeps track of its progress so a new one should be created for each wait.
+func Sequence(steps ...WaitCondition) WaitCondition {
+	current := 0
+	return func() (bool, error) {
+		for current < len(steps) {
+			done, err := steps[current]()
+			if err != nil || !done {
+				return false, err
+			}
+			current++
+			logger.Log("Sequence step %d/%d done", current, len(steps))
+		}
+		return true, nil
+	}
+}
+
+// StableFor returns a WaitCondition that is only done once the provided WaitCondition has been done on every check
+// for at least the given duration. If the condition isn't done, or returns an error, the duration starts again. Errors
+// are logged rather than returned, like with `Any`, so a transient error doesn't abort the wait.
+//
+// As the condition is only checked when polled, changes between checks aren't detected.
+// The returned WaitCondition keeps track of its progress so a new one should be created for each wait.
+func StableFor(condition WaitCondition, duration time.Duration) WaitCondition {
+	check := newStableCheck(duration)
+	return func() (bool, error) {
+		done, err := condition()
+		if err != nil {
+			logger.Log("Condition not met - %v", err)
+		}
+		return check.update(done && err == nil), nil
+	}
+}
+
+// FromWaitConditionSlice adapts a WaitConditionSlice to a WaitCondition that is done once the slice is empty, so it
+// can be combined with other WaitConditions
+func FromWaitConditionSlice(condition WaitConditionSlice) WaitCondition {
+	return func() (bool, error) {
+		result, err := condition()
+		if err != nil {
+			return false, err
+		}
+		return len(result) == 0, nil
+	}
+}
+
+// ToWaitConditionSlice adapts a WaitCondition to a WaitConditionSlice that contains the provided description while
+// the condition isn't done, so it can be combined with other WaitConditionSlices
+func ToWaitConditionSlice(condition WaitCondition, description string) WaitConditionSlice {
+	return func() ([]any, error) {
+		done, err := condition()
+		if err != nil || !done {
+			return []any{description}, err
+		}
+		return []any{}, nil
+	}
+}
+
+// AllSlice returns a WaitConditionSlice that contains the results of all the provided WaitConditionSlices, so it's
+// only empty once all of them are. All the conditions are checked so the result includes everything not yet ready.
+func AllSlice(conditions ...WaitConditionSlice) WaitConditionSlice {
+	return func() ([]any, error) {
+		results := []any{}
+		errs := []error{}
+		for _, condition := range conditions {
+			result, err := condition()
+			results = append(results, result...)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return results, utilerrors.NewAggregate(errs)
+	}
+}
+
+// AnySlice returns a WaitConditionSlice that is empty as soon as any of the provided WaitConditionSlices is empty,
+// otherwise it contains the results of all of them. Errors are treated as the condition not being met (and logged).
+func AnySlice(conditions ...WaitConditionSlice) WaitConditionSlice {
+	return func() ([]any, error) {
+		results := []any{}
+		for _, condition := range conditions {
+			result, err := condition()
+			if err != nil {
+				logger.Log("Condition not met - %v", err)
+				results = append(results, result...)
+				continue
+			}
+			if len(result) == 0 {
+				return []any{}, nil
+			}
+			results = append(results, result...)
+		}
+		return results, nil
+	}
+}
+
+// SequenceSlice returns a WaitConditionSlice that requires the provided WaitConditionSlices to be empty in order,
+// containing the results of the current step. Once a step is empty it isn't checked again.
+//
+// The returned WaitConditionSlice keeps track of its progress so a new one should be created for each wait.
+func SequenceSlice(steps ...WaitConditionSlice) WaitConditionSlice {
+	current := 0
+	return func() ([]any, error) {
+		for current < len(steps) {
+			result, err := steps[current]()
+			if err != nil || len(result) > 0 {
+				return result, err
+			}
+			current++
+			logger.Log("Sequence step %d/%d done", current, len(steps))
+		}
+		return []any{}, nil
+	}
+}
+
+// StableForSlice returns a WaitConditionSlice that is only empty once the provided WaitConditionSlice has been empty
+// on every check for at least the given duration. Until then it contains the results of the condition or, if those are
+// empty, a description of how long it has been stable for. If the condition returns an error the duration starts again
+// and the error is logged and described in the result rather than returned, so a transient error doesn't abort the wait.
+//
+// The returned WaitConditionSlice keeps track of its progress so a new one should be created for each wait.
+func StableForSlice(condition WaitConditionSlice, duration time.Duration) WaitConditionSlice {
+	check := newStableCheck(duration)
+	return func() ([]any, error) {
+		result, err := condition()
+		if check.update(len(result) == 0 && err == nil) {
+			return []any{}, nil
+		}
+		if err != nil {
+			logger.Log("Condition not met - %v", err)
+			return append(result, fmt.Sprintf("condition failed - %v", err)), nil
+		}
+		if len(result) > 0 {
+			return result, nil
+		}
+		return []any{fmt.Sprintf("stable for %s of %s", check.stableFor().Round(time.Second), duration)}, nil
+	}
+}
+
+// stableCheck tracks how long a condition has continuously been met
+type stableCheck struct {
+	duration time.Duration
+	since    time.Time
+}
+
+func newStableCheck(duration time.Duration) *stableCheck {
+	return &stableCheck{duration: duration}
+}
+
+// update records the latest result of the condition and returns if it has been met for at least the duration
+func (s *stableCheck) update(met bool) bool {
+	if !met {
+		s.since = time.Time{}
+		return false
+	}
+	if s.since.IsZero() {
+		s.since = time.Now()
+	}
+	return s.stableFor() >= s.duration
+}
+
+func (s *stableCheck) stableFor() time.Duration {
+	if s.since.IsZero() {
+		return 0
+	}
+	return time.Since(s.since)
+}
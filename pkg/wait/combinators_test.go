@@ -0,0 +1,212 @@
+package wait
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errCondition = errors.New("condition failed")
+
+func staticCondition(done bool, err error) WaitCondition {
+	return func() (bool, error) {
+		return done, err
+	}
+}
+
+func staticSlice(result []any, err error) WaitConditionSlice {
+	return func() ([]any, error) {
+		return result, err
+	}
+}
+
+// sequenceOf returns a WaitCondition returning each of the provided results in turn, repeating the last one
+func sequenceOf(results ...bool) WaitCondition {
+	i := 0
+	return func() (bool, error) {
+		result := results[i]
+		if i < len(results)-1 {
+			i++
+		}
+		return result, nil
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	tests := []struct {
+		name         string
+		condition    WaitCondition
+		expectedDone bool
+		expectedErr  bool
+	}{
+		{name: "All: all done", condition: All(staticCondition(true, nil), staticCondition(true, nil)), expectedDone: true},
+		{name: "All: one not done", condition: All(staticCondition(true, nil), staticCondition(false, nil))},
+		{name: "All: error", condition: All(staticCondition(true, nil), staticCondition(false, errCondition)), expectedErr: true},
+		{name: "All: none", condition: All(), expectedDone: true},
+		{name: "Any: one done", condition: Any(staticCondition(false, nil), staticCondition(true, nil)), expectedDone: true},
+		{name: "Any: none done", condition: Any(staticCondition(false, nil), staticCondition(false, nil))},
+		{name: "Any: errors ignored", condition: Any(staticCondition(false, errCondition), staticCondition(true, nil)), expectedDone: true},
+		{name: "Any: only errors", condition: Any(staticCondition(false, errCondition))},
+		{name: "Not: done", condition: Not(staticCondition(true, nil))},
+		{name: "Not: not done", condition: Not(staticCondition(false, nil)), expectedDone: true},
+		{name: "Not: error", condition: Not(staticCondition(false, errCondition)), expectedErr: true},
+		{name: "FromWaitConditionSlice: empty", condition: FromWaitConditionSlice(staticSlice([]any{}, nil)), expectedDone: true},
+		{name: "FromWaitConditionSlice: not empty", condition: FromWaitConditionSlice(staticSlice([]any{"pod"}, nil))},
+		{name: "FromWaitConditionSlice: error", condition: FromWaitConditionSlice(staticSlice(nil, errCondition)), expectedErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			done, err := tc.condition()
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("Error not as expected. Expected error: %t, Actual: %v", tc.expectedErr, err)
+			}
+			if done != tc.expectedDone {
+				t.Errorf("Done not as expected. Expected: %t, Actual: %t", tc.expectedDone, done)
+			}
+		})
+	}
+}
+
+func TestSequence(t *testing.T) {
+	firstChecks := 0
+	first := func() (bool, error) {
+		firstChecks++
+		return true, nil
+	}
+	second := sequenceOf(false, true)
+
+	condition := Sequence(first, second)
+
+	if done, err := condition(); done || err != nil {
+		t.Errorf("Expected sequence not to be done while the second step isn't done. Actual: %t, %v", done, err)
+	}
+	if done, err := condition(); !done || err != nil {
+		t.Errorf("Expected sequence to be done. Actual: %t, %v", done, err)
+	}
+	if firstChecks != 1 {
+		t.Errorf("Expected the first step to only be checked until it was done. Actual: %d checks", firstChecks)
+	}
+
+	if done, err := Sequence(staticCondition(false, errCondition), first)(); done || !errors.Is(err, errCondition) {
+		t.Errorf("Expected the step error to be returned. Actual: %t, %v", done, err)
+	}
+}
+
+func TestStableFor(t *testing.T) {
+	condition := StableFor(sequenceOf(true, false, true), 50*time.Millisecond)
+
+	// Met, then not met which resets the duration
+	for i := 0; i < 2; i++ {
+		if done, _ := condition(); done {
+			t.Fatalf("Not expecting the condition to be stable yet")
+		}
+	}
+	if done, _ := condition(); done {
+		t.Fatalf("Not expecting the condition to be stable before the duration has passed")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if done, err := condition(); !done || err != nil {
+		t.Errorf("Expected the condition to be stable. Actual: %t, %v", done, err)
+	}
+
+	// Errors reset the duration rather than being returned
+	failing := true
+	condition = StableFor(func() (bool, error) {
+		if failing {
+			return false, errCondition
+		}
+		return true, nil
+	}, 50*time.Millisecond)
+	if done, err := condition(); done || err != nil {
+		t.Errorf("Expected the error to not be met and not returned. Actual: %t, %v", done, err)
+	}
+	failing = false
+	if done, _ := condition(); done {
+		t.Fatalf("Expected the duration to start again after the error")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if done, err := condition(); !done || err != nil {
+		t.Errorf("Expected the condition to be stable. Actual: %t, %v", done, err)
+	}
+
+	if done, err := StableFor(staticCondition(true, nil), 0)(); !done || err != nil {
+		t.Errorf("Expected the condition to be stable straight away with no duration. Actual: %t, %v", done, err)
+	}
+}
+
+func TestSliceCombinators(t *testing.T) {
+	tests := []struct {
+		name           string
+		condition      WaitConditionSlice
+		expectedResult []any
+		expectedErr    bool
+	}{
+		{name: "AllSlice: all empty", condition: AllSlice(staticSlice([]any{}, nil), staticSlice(nil, nil)), expectedResult: []any{}},
+		{name: "AllSlice: combined results", condition: AllSlice(staticSlice([]any{"a"}, nil), staticSlice([]any{"b"}, nil)), expectedResult: []any{"a", "b"}},
+		{name: "AllSlice: error", condition: AllSlice(staticSlice([]any{"a"}, errCondition), staticSlice([]any{}, nil)), expectedResult: []any{"a"}, expectedErr: true},
+		{name: "AnySlice: one empty", condition: AnySlice(staticSlice([]any{"a"}, nil), staticSlice([]any{}, nil)), expectedResult: []any{}},
+		{name: "AnySlice: none empty", condition: AnySlice(staticSlice([]any{"a"}, nil), staticSlice([]any{"b"}, nil)), expectedResult: []any{"a", "b"}},
+		{name: "AnySlice: errors ignored", condition: AnySlice(staticSlice(nil, errCondition), staticSlice([]any{}, nil)), expectedResult: []any{}},
+		{name: "ToWaitConditionSlice: done", condition: ToWaitConditionSlice(staticCondition(true, nil), "thing"), expectedResult: []any{}},
+		{name: "ToWaitConditionSlice: not done", condition: ToWaitConditionSlice(staticCondition(false, nil), "thing"), expectedResult: []any{"thing"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.condition()
+			if (err != nil) != tc.expectedErr {
+				t.Errorf("Error not as expected. Expected error: %t, Actual: %v", tc.expectedErr, err)
+			}
+			if len(result) != len(tc.expectedResult) {
+				t.Fatalf("Result not as expected. Expected: %v, Actual: %v", tc.expectedResult, result)
+			}
+			for i := range result {
+				if result[i] != tc.expectedResult[i] {
+					t.Errorf("Result not as expected. Expected: %v, Actual: %v", tc.expectedResult, result)
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceSlice(t *testing.T) {
+	second := 0
+	condition := SequenceSlice(
+		staticSlice([]any{}, nil),
+		func() ([]any, error) {
+			second++
+			if second < 2 {
+				return []any{"pod"}, nil
+			}
+			return []any{}, nil
+		},
+	)
+
+	if result, err := condition(); len(result) != 1 || err != nil {
+		t.Errorf("Expected the results of the current step. Actual: %v, %v", result, err)
+	}
+	if result, err := condition(); len(result) != 0 || err != nil {
+		t.Errorf("Expected the sequence to be done. Actual: %v, %v", result, err)
+	}
+}
+
+func TestStableForSlice(t *testing.T) {
+	condition := StableForSlice(staticSlice([]any{}, nil), 50*time.Millisecond)
+
+	if result, _ := condition(); len(result) != 1 {
+		t.Errorf("Expected a description while not yet stable. Actual: %v", result)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if result, err := condition(); len(result) != 0 || err != nil {
+		t.Errorf("Expected the condition to be stable. Actual: %v, %v", result, err)
+	}
+
+	if result, _ := StableForSlice(staticSlice([]any{"pod"}, nil), 0)(); len(result) != 1 || result[0] != "pod" {
+		t.Errorf("Expected the condition results while not met. Actual: %v", result)
+	}
+	if result, err := StableForSlice(staticSlice(nil, errCondition), 0)(); len(result) != 1 || err != nil {
+		t.Errorf("Expected the error to be described rather than returned. Actual: %v, %v", result, err)
+	}
+}
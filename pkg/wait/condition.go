@@ -0,0 +1,156 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// Condition is a context-aware condition check for if we need to keep waiting. It is provided the context of the
+// current attempt and, if not yet done, returns a human-readable reason why so it can be reported on timeout.
+//
+// Example:
+//
+//	func IsConfigMapPresent(kubeClient *client.Client, name, namespace string) wait.Condition {
+//		return func(ctx context.Context) (bool, string, error) {
+//			err := kubeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &corev1.ConfigMap{})
+//			if apierrors.IsNotFound(err) {
+//				return false, fmt.Sprintf("configmap %s/%s not found", namespace, name), nil
+//			}
+//			return err == nil, "", err
+//		}
+//	}
+type Condition func(ctx context.Context) (done bool, reason string, err error)
+
+// TimeoutError is returned by `ForCondition` when the context is done before the Condition is met
+type TimeoutError struct {
+	// LastReason is the reason returned by the last attempt the Condition wasn't met
+	LastReason string
+	// Attempts is the number of times the Condition was evaluated
+	Attempts int
+	// Elapsed is how long was spent waiting
+	Elapsed time.Duration
+	// Err is the underlying context error, e.g. `context.DeadlineExceeded`
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	reason := e.LastReason
+	if reason == "" {
+		reason = "no reason given"
+	}
+	return fmt.Sprintf("condition not met after %s (%d attempts): %s - %v", e.Elapsed.Round(time.Millisecond), e.Attempts, reason, e.Err)
+}
+
+// Unwrap returns the underlying context error so `errors.Is(err, context.DeadlineExceeded)` can be used
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// ForCondition continuously evaluates the provided Condition until either the timeout is reached or the Condition
+// returns as done. It accepts the same options as `For`, including `WithWatch`.
+//
+// If the timeout is reached a `TimeoutError` is returned containing the last reason the Condition wasn't met.
+//
+// Example:
+//
+//	err := wait.ForCondition(IsConfigMapPresent(kubeClient, "example", "default"), wait.WithTimeout(5*time.Minute))
+//	var timeoutErr *wait.TimeoutError
+//	if errors.As(err, &timeoutErr) {
+//		logger.Log("Gave up after %d attempts: %s", timeoutErr.Attempts, timeoutErr.LastReason)
+//	}
+func ForCondition(cond Condition, opts ...Option) error {
+	options := newOptions(opts)
+
+	p, err := run(cond, options)
+	if err != nil && options.Context.Err() != nil {
+		return p.timeoutError(err)
+	}
+	return err
+}
+
+// FromWaitCondition adapts an existing WaitCondition to a Condition so it can be used with `ForCondition`.
+// As WaitConditions don't provide a reason a generic one is used when not done.
+func FromWaitCondition(wc WaitCondition) Condition {
+	return func(_ context.Context) (bool, string, error) {
+		done, err := wc()
+		if err != nil || done {
+			return done, "", err
+		}
+		return false, "condition not yet met", nil
+	}
+}
+
+// ToWaitCondition adapts a Condition to a WaitCondition, evaluating it with the provided context, so it can be used
+// where a WaitCondition is expected
+func ToWaitCondition(ctx context.Context, cond Condition) WaitCondition {
+	return func() (bool, error) {
+		done, _, err := cond(ctx)
+		return done, err
+	}
+}
+
+// ConditionWithoutDone returns a function for use with Gomega's `Eventually` that returns an error with the reason
+// the Condition isn't yet met, or nil once it is, so the last reason is included in the failure message.
+//
+// Example:
+//
+//	Eventually(wait.ConditionWithoutDone(IsConfigMapPresent(kubeClient, "example", "default"))).
+//		WithContext(ctx).
+//		WithTimeout(5 * time.Minute).
+//		Should(Succeed())
+func ConditionWithoutDone(cond Condition) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		done, reason, err := cond(ctx)
+		if err != nil {
+			return err
+		}
+		if !done {
+			return fmt.Errorf("condition not met: %s", reason)
+		}
+		return nil
+	}
+}
+
+// progress tracks the attempts made while waiting on a Condition
+type progress struct {
+	start      time.Time
+	attempts   int
+	lastReason string
+}
+
+// evaluate runs a single attempt of the Condition, recording the reason if it isn't done
+func (p *progress) evaluate(ctx context.Context, cond Condition, options *Options) (bool, error) {
+	p.attempts++
+
+	attemptCtx := ctx
+	if options.AttemptTimeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, options.AttemptTimeout)
+		defer cancel()
+	}
+
+	done, reason, err := cond(attemptCtx)
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		// Only this attempt timed out so keep on waiting
+		done, reason, err = false, fmt.Sprintf("attempt timed out after %s - %v", options.AttemptTimeout, err), nil
+	}
+	if err == nil && !done {
+		p.lastReason = reason
+		if options.LogProgressEvery > 0 && p.attempts%options.LogProgressEvery == 0 {
+			logger.Log("Still waiting after %d attempts (%s): %s", p.attempts, time.Since(p.start).Round(time.Second), reason)
+		}
+	}
+	return done, err
+}
+
+func (p *progress) timeoutError(err error) *TimeoutError {
+	return &TimeoutError{
+		LastReason: p.lastReason,
+		Attempts:   p.attempts,
+		Elapsed:    time.Since(p.start),
+		Err:        err,
+	}
+}
@@ -0,0 +1,104 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestForCondition(t *testing.T) {
+	attempts := 0
+	cond := func(_ context.Context) (bool, string, error) {
+		attempts++
+		return attempts == 3, fmt.Sprintf("attempt %d", attempts), nil
+	}
+
+	if err := ForCondition(cond, WithInterval(time.Millisecond), WithTimeout(5*time.Second)); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts. Actual: %d", attempts)
+	}
+}
+
+func TestForCondition_Timeout(t *testing.T) {
+	attempts := 0
+	cond := func(ctx context.Context) (bool, string, error) {
+		if ctx == nil {
+			return false, "", errors.New("expected a context")
+		}
+		attempts++
+		return false, fmt.Sprintf("%d/3 replicas ready", attempts), nil
+	}
+
+	err := ForCondition(cond, WithInterval(10*time.Millisecond), WithTimeout(100*time.Millisecond))
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("Expected a TimeoutError to be returned. Actual: %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the error to wrap context.DeadlineExceeded")
+	}
+	if timeoutErr.Attempts != attempts || attempts < 2 {
+		t.Errorf("Attempts not as expected. Expected: %d, Actual: %d", attempts, timeoutErr.Attempts)
+	}
+	if expected := fmt.Sprintf("%d/3 replicas ready", attempts); timeoutErr.LastReason != expected {
+		t.Errorf("LastReason not as expected. Expected: %s, Actual: %s", expected, timeoutErr.LastReason)
+	}
+	if timeoutErr.Elapsed < 100*time.Millisecond {
+		t.Errorf("Expected Elapsed to be at least the timeout. Actual: %s", timeoutErr.Elapsed)
+	}
+	if !strings.Contains(err.Error(), timeoutErr.LastReason) {
+		t.Errorf("Expected the error message to contain the last reason. Actual: %s", err)
+	}
+}
+
+func TestForCondition_Error(t *testing.T) {
+	expectedErr := errors.New("failed")
+	err := ForCondition(func(_ context.Context) (bool, string, error) {
+		return false, "", expectedErr
+	}, WithTimeout(time.Second))
+
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected the condition error to be returned. Actual: %v", err)
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		t.Errorf("Not expecting a TimeoutError to be returned")
+	}
+}
+
+func TestFromWaitCondition(t *testing.T) {
+	done := false
+	cond := FromWaitCondition(func() (bool, error) { return done, nil })
+
+	ok, reason, err := cond(context.Background())
+	if ok || reason == "" || err != nil {
+		t.Errorf("Expected not done with a reason. Actual: %t, %q, %v", ok, reason, err)
+	}
+
+	done = true
+	if ok, _, err := cond(context.Background()); !ok || err != nil {
+		t.Errorf("Expected done. Actual: %t, %v", ok, err)
+	}
+}
+
+func TestConditionWithoutDone(t *testing.T) {
+	done := false
+	fn := ConditionWithoutDone(func(_ context.Context) (bool, string, error) {
+		return done, "still waiting", nil
+	})
+
+	if err := fn(context.Background()); err == nil || !strings.Contains(err.Error(), "still waiting") {
+		t.Errorf("Expected an error with the reason. Actual: %v", err)
+	}
+
+	done = true
+	if err := fn(context.Background()); err != nil {
+		t.Errorf("Not expecting an error to be returned - %v", err)
+	}
+}
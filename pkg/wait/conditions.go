@@ -92,6 +92,9 @@ func ConsistentWaitCondition(wc WaitCondition, attempts int, pollInterval time.D
 // Additionally IsClusterReadyCondition accepts a pointer to a `client.Client` which will be set
 // to a working workload cluster client once the condition is met. This allows the caller to use
 // the client directly after the condition is met without needing to re-create the client.
+//
+// The workload cluster kubeconfig is found using the KubeconfigProviders configured on the provided MC `kubeClient`
+// (see `client.SetKubeconfigProviders`).
 func IsClusterReadyCondition(ctx context.Context, kubeClient *client.Client, clusterName string, namespace string, clientPtr **client.Client) WaitCondition {
 	return func() (bool, error) {
 		select {
@@ -158,15 +161,20 @@ func DoesResourceExist(ctx context.Context, kubeClient *client.Client, resource
 }
 
 // AreAllDeploymentsReady returns a WaitCondition that checks if all Deployments found in the cluster have the expected number of replicas ready
-func AreAllDeploymentsReady(ctx context.Context, kubeClient *client.Client) WaitCondition {
+//
+// The resources checked can be scoped with list options, such as `cr.InNamespace`, label selectors or `ExcludeResources`.
+func AreAllDeploymentsReady(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitCondition {
 	return func() (bool, error) {
 		deploymentList := &appsv1.DeploymentList{}
-		err := kubeClient.List(ctx, deploymentList)
+		err := kubeClient.List(ctx, deploymentList, listOptions...)
 		if err != nil {
 			return false, err
 		}
 
 		for _, deployment := range deploymentList.Items {
+			if isExcluded(&deployment, listOptions) {
+				continue
+			}
 			available := deployment.Status.AvailableReplicas
 			desired := *deployment.Spec.Replicas
 			if available != desired {
@@ -181,15 +189,20 @@ func AreAllDeploymentsReady(ctx context.Context, kubeClient *client.Client) Wait
 }
 
 // AreAllStatefulSetsReady returns a WaitCondition that checks if all StatefulSets found in the cluster have the expected number of replicas ready
-func AreAllStatefulSetsReady(ctx context.Context, kubeClient *client.Client) WaitCondition {
+//
+// The resources checked can be scoped with list options, see `AreAllDeploymentsReady`.
+func AreAllStatefulSetsReady(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitCondition {
 	return func() (bool, error) {
 		statefulSetList := &appsv1.StatefulSetList{}
-		err := kubeClient.List(ctx, statefulSetList)
+		err := kubeClient.List(ctx, statefulSetList, listOptions...)
 		if err != nil {
 			return false, err
 		}
 
 		for _, statefulSet := range statefulSetList.Items {
+			if isExcluded(&statefulSet, listOptions) {
+				continue
+			}
 			available := statefulSet.Status.AvailableReplicas
 			desired := *statefulSet.Spec.Replicas
 			if available != desired {
@@ -204,15 +217,20 @@ func AreAllStatefulSetsReady(ctx context.Context, kubeClient *client.Client) Wai
 }
 
 // AreAllDaemonSetsReady returns a WaitCondition that checks if all DaemonSets found in the cluster have the expected number of replicas ready
-func AreAllDaemonSetsReady(ctx context.Context, kubeClient *client.Client) WaitCondition {
+//
+// The resources checked can be scoped with list options, see `AreAllDeploymentsReady`.
+func AreAllDaemonSetsReady(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitCondition {
 	return func() (bool, error) {
 		daemonSetList := &appsv1.DaemonSetList{}
-		err := kubeClient.List(ctx, daemonSetList)
+		err := kubeClient.List(ctx, daemonSetList, listOptions...)
 		if err != nil {
 			return false, err
 		}
 
 		for _, daemonSet := range daemonSetList.Items {
+			if isExcluded(&daemonSet, listOptions) {
+				continue
+			}
 			current := daemonSet.Status.CurrentNumberScheduled
 			desired := daemonSet.Status.DesiredNumberScheduled
 			if current != desired {
@@ -227,16 +245,21 @@ func AreAllDaemonSetsReady(ctx context.Context, kubeClient *client.Client) WaitC
 }
 
 // AreAllJobsSucceeded returns a WaitCondition that checks if all Jobs found in the cluster have completed successfully
-func AreAllJobsSucceeded(ctx context.Context, kubeClient *client.Client) WaitCondition {
+//
+// The resources checked can be scoped with list options, see `AreAllDeploymentsReady`.
+func AreAllJobsSucceeded(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitCondition {
 	return func() (bool, error) {
 		jobList := &batchv1.JobList{}
-		err := kubeClient.List(ctx, jobList)
+		err := kubeClient.List(ctx, jobList, listOptions...)
 		if err != nil {
 			return false, err
 		}
 
 		var loopErr error
 		for _, job := range jobList.Items {
+			if isExcluded(&job, listOptions) {
+				continue
+			}
 			if job.Status.Succeeded == 0 && job.Status.Active == 0 {
 				logger.Log("Job %s/%s has not succeeded. (Failed: '%d')", job.Namespace, job.Name, job.Status.Failed)
 				// We wrap the errors so that we can log out for all failures, not just the first found
@@ -257,15 +280,20 @@ func AreAllJobsSucceeded(ctx context.Context, kubeClient *client.Client) WaitCon
 }
 
 // AreAllPodsInSuccessfulPhase returns a WaitCondition that checks if all Pods found in the cluster are in a successful phase (e.g. running or completed)
-func AreAllPodsInSuccessfulPhase(ctx context.Context, kubeClient *client.Client) WaitCondition {
+//
+// The resources checked can be scoped with list options, see `AreAllDeploymentsReady`.
+func AreAllPodsInSuccessfulPhase(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitCondition {
 	return func() (bool, error) {
 		podList := &corev1.PodList{}
-		err := kubeClient.List(ctx, podList)
+		err := kubeClient.List(ctx, podList, listOptions...)
 		if err != nil {
 			return false, err
 		}
 
 		for _, pod := range podList.Items {
+			if isExcluded(&pod, listOptions) {
+				continue
+			}
 			phase := pod.Status.Phase
 			if phase != corev1.PodRunning && phase != corev1.PodSucceeded {
 				logger.Log("pod %s/%s in %s phase", pod.Namespace, pod.Name, phase)
@@ -0,0 +1,112 @@
+package wait
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+)
+
+func newDeployment(namespace, name string, desired, available int32, labels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec:       appsv1.DeploymentSpec{Replicas: &desired},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+}
+
+func newPod(namespace, name string, phase corev1.PodPhase, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestAreAllDeploymentsReady_ListOptions(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := &client.Client{Client: fake.NewClientBuilder().WithObjects(
+		newDeployment("my-app", "api", 2, 2, map[string]string{"app": "my-app"}),
+		newDeployment("kube-system", "coredns", 2, 1, nil),
+		newDeployment("monitoring", "flaky-exporter", 1, 0, nil),
+	).Build()}
+
+	tests := []struct {
+		name           string
+		listOptions    []cr.ListOption
+		expectedReady  bool
+		expectedFailed int
+	}{
+		{name: "cluster-wide", expectedFailed: 2},
+		{name: "namespace", listOptions: []cr.ListOption{cr.InNamespace("my-app")}, expectedReady: true},
+		{name: "labels", listOptions: []cr.ListOption{cr.MatchingLabels{"app": "my-app"}}, expectedReady: true},
+		{name: "exclusions", listOptions: []cr.ListOption{ExcludeResources{"kube-system/*", "*/flaky-*"}}, expectedReady: true},
+		{name: "partial exclusions", listOptions: []cr.ListOption{ExcludeResources{"kube-system/coredns"}}, expectedFailed: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ready, _ := AreAllDeploymentsReady(ctx, kubeClient, tc.listOptions...)()
+			if ready != tc.expectedReady {
+				t.Errorf("Ready not as expected. Expected: %t, Actual: %t", tc.expectedReady, ready)
+			}
+
+			failing, err := AreAllDeploymentsReadySlice(ctx, kubeClient, tc.listOptions...)()
+			if err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if len(failing) != tc.expectedFailed {
+				t.Errorf("Failing deployments not as expected. Expected: %d, Actual: %v", tc.expectedFailed, failing)
+			}
+		})
+	}
+}
+
+func TestAreAllPodsInSuccessfulPhase_ListOptions(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := &client.Client{Client: fake.NewClientBuilder().WithObjects(
+		newPod("my-app", "api", corev1.PodRunning, nil),
+		newPod("my-app", "migration", corev1.PodSucceeded, nil),
+		newPod("kube-system", "node-problem-detector", corev1.PodPending, map[string]string{"flaky": "true"}),
+	).Build()}
+
+	if ready, _ := AreAllPodsInSuccessfulPhase(ctx, kubeClient)(); ready {
+		t.Errorf("Expected pods to not all be in a successful phase when listed cluster-wide")
+	}
+	if ready, err := AreAllPodsInSuccessfulPhase(ctx, kubeClient, client.DoesNotHaveLabels{"flaky"})(); !ready || err != nil {
+		t.Errorf("Expected labelled pods to be filtered out. Actual: %t, %v", ready, err)
+	}
+	if failing, _ := AreAllPodsInSuccessfulPhaseSlice(ctx, kubeClient, ExcludeResources{"my-app/*"})(); len(failing) != 1 || failing[0] != "kube-system/node-problem-detector" {
+		t.Errorf("Expected only the pods not excluded to be returned. Actual: %v", failing)
+	}
+}
+
+func TestIsExcluded(t *testing.T) {
+	obj := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "coredns-abc", Namespace: "kube-system"}}
+
+	tests := []struct {
+		name        string
+		listOptions []cr.ListOption
+		expected    bool
+	}{
+		{name: "no options", expected: false},
+		{name: "exact", listOptions: []cr.ListOption{ExcludeResources{"kube-system/coredns-abc"}}, expected: true},
+		{name: "namespace", listOptions: []cr.ListOption{ExcludeResources{"kube-system/*"}}, expected: true},
+		{name: "name prefix", listOptions: []cr.ListOption{ExcludeResources{"*/coredns-*"}}, expected: true},
+		{name: "no match", listOptions: []cr.ListOption{ExcludeResources{"default/*"}, cr.InNamespace("kube-system")}, expected: false},
+		{name: "invalid pattern", listOptions: []cr.ListOption{ExcludeResources{"[", "kube-system/*"}}, expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := isExcluded(obj, tc.listOptions); actual != tc.expected {
+				t.Errorf("Excluded not as expected. Expected: %t, Actual: %t", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -25,6 +25,27 @@
 //		30*time.Second,
 //	).Should(BeTrue())
 //
+// # Example using `For` with a watch instead of polling
+//
+// Providing `WithWatch` evaluates the condition only when the watched objects change (with a periodic resync as a
+// fallback) rather than every interval, reducing the load on the API server.
+//
+//	err := wait.For(
+//		wait.AreAllDeploymentsReady(ctx, wcClient),
+//		wait.WithWatch(wcClient, &appsv1.DeploymentList{}),
+//		wait.WithContext(ctx),
+//	)
+//
+// # Example using `ForCondition` with a context-aware `Condition`
+//
+// A `Condition` receives the context of each attempt and returns a reason when it isn't yet met. If the timeout is
+// reached `ForCondition` returns a `TimeoutError` including the last reason, rather than just the context error.
+//
+//	err := wait.ForCondition(
+//		wait.FromWaitCondition(wait.IsAppDeployed(ctx, client, appName, namespace)),
+//		wait.WithTimeout(10*time.Minute),
+//	)
+//
 // The WaitCondition functions return a success boolean and an error. The polling of the condition will
 // continue until one of three things occurs:
 //
@@ -0,0 +1,45 @@
+package wait
+
+import (
+	"path"
+
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+// ExcludeResources is a list option that excludes resources from being checked by the `AreAll*` conditions, e.g.
+// known-flaky system components. Each entry is a `namespace/name` pattern which supports the wildcards of
+// `path.Match`, such as `kube-system/*` to exclude a whole namespace or `*/coredns-*` to exclude by name.
+//
+// It can be provided alongside any other list options, such as `cr.InNamespace` or `client.DoesNotHaveLabels`.
+//
+// Example:
+//
+//	wait.AreAllDeploymentsReady(ctx, wcClient,
+//		cr.MatchingLabels{"app.kubernetes.io/part-of": "my-app"},
+//		wait.ExcludeResources{"kube-system/*", "*/flaky-deployment"},
+//	)
+type ExcludeResources []string
+
+// ApplyToList doesn't modify the list options as resources are excluded after being listed
+func (e ExcludeResources) ApplyToList(_ *cr.ListOptions) {}
+
+// isExcluded returns true if the object matches any of the ExcludeResources in the provided list options
+func isExcluded(obj cr.Object, listOptions []cr.ListOption) bool {
+	namespacedName := obj.GetNamespace() + "/" + obj.GetName()
+	for _, opt := range listOptions {
+		exclusions, ok := opt.(ExcludeResources)
+		if !ok {
+			continue
+		}
+		for _, pattern := range exclusions {
+			if matched, err := path.Match(pattern, namespacedName); err != nil {
+				logger.Log("Invalid exclusion pattern '%s', skipping... - %v", pattern, err)
+			} else if matched {
+				return true
+			}
+		}
+	}
+	return false
+}
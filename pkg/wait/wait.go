@@ -2,6 +2,7 @@ package wait
 
 import (
 	"context"
+	"math/rand/v2"
 	"time"
 )
 
@@ -17,6 +18,22 @@ type Options struct {
 	Context  context.Context
 	Interval time.Duration
 	// Timeout  time.Duration
+	// ResyncInterval is the interval the WaitCondition is evaluated at when watching if no changes have been seen
+	ResyncInterval time.Duration
+	// BackoffFactor multiplies the interval after each attempt, e.g. 2 to double it. Values <= 1 keep a fixed interval.
+	BackoffFactor float64
+	// MaxInterval caps the interval when backing off. Defaults to no maximum.
+	MaxInterval time.Duration
+	// Jitter randomly increases each interval by up to this fraction of it, e.g. 0.1 for up to 10% longer
+	Jitter float64
+	// Immediate checks the condition straight away rather than after the first interval. Defaults to true.
+	Immediate bool
+	// AttemptTimeout is the timeout of the context provided to each attempt. Defaults to no timeout per attempt.
+	AttemptTimeout time.Duration
+	// LogProgressEvery logs the progress of waiting every this many attempts. Defaults to not logging progress.
+	LogProgressEvery int
+
+	watches []watchSource
 }
 
 // Option is a function that can be optionally provided to override default options of a wait condition
@@ -46,38 +63,133 @@ func WithContext(context context.Context) Option {
 	}
 }
 
+// WithBackoff increases the polling interval by the given factor after each attempt, up to `maxInterval` (if not 0).
+// This avoids polling a slow condition needlessly often, e.g. `WithBackoff(2, 2*time.Minute)` doubles the interval
+// each attempt up to a max of 2 minutes.
+func WithBackoff(factor float64, maxInterval time.Duration) Option {
+	return func(options *Options) {
+		options.BackoffFactor = factor
+		options.MaxInterval = maxInterval
+	}
+}
+
+// WithJitter randomly increases each polling interval by up to the given fraction of it (e.g. 0.2 for up to 20%)
+// so that many waits started at the same time don't poll in lockstep
+func WithJitter(fraction float64) Option {
+	return func(options *Options) {
+		options.Jitter = fraction
+	}
+}
+
+// WithImmediate sets whether the condition is checked straight away (the default) or only after the first interval
+func WithImmediate(immediate bool) Option {
+	return func(options *Options) {
+		options.Immediate = immediate
+	}
+}
+
+// WithAttemptTimeout sets a timeout for each attempt. The timeout is applied to the context provided to a `Condition`,
+// an attempt failing due to it is treated as the condition not being met rather than an error.
+// A `WaitCondition` uses the context it was created with so isn't affected by this.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(options *Options) {
+		options.AttemptTimeout = timeout
+	}
+}
+
+// WithLogProgressEvery logs the number of attempts, time spent waiting and, for a `Condition`, the reason it isn't yet
+// met every given number of attempts
+func WithLogProgressEvery(attempts int) Option {
+	return func(options *Options) {
+		options.LogProgressEvery = attempts
+	}
+}
+
 // For continuously polls the provided WaitCondition function until either
-// the timeout is reached or the function returns as done
+// the timeout is reached or the function returns as done.
+//
+// If `WithWatch` is provided the WaitCondition is instead evaluated when the watched objects change.
+//
+// See `ForCondition` for waiting on a context-aware `Condition` with detailed timeout errors.
 func For(fn WaitCondition, opts ...Option) error {
+	_, err := run(FromWaitCondition(fn), newOptions(opts))
+	return err
+}
+
+// newOptions returns the Options with the provided overrides applied to the defaults
+func newOptions(opts []Option) *Options {
 	//nolint:govet
 	defaultContext, _ := context.WithTimeout(context.Background(), DefaultTimeout) // #nosec G118
 	options := &Options{
-		Context:  defaultContext,
-		Interval: DefaultInterval,
+		Context:   defaultContext,
+		Interval:  DefaultInterval,
+		Immediate: true,
 	}
 	for _, optFn := range opts {
 		optFn(options)
 	}
+	return options
+}
 
+// run evaluates the Condition, either polling it or when the watched objects change, until either the context is done
+// or the Condition returns as done
+func run(cond Condition, options *Options) (*progress, error) {
 	ctx, cancel := context.WithCancel(options.Context)
 	defer cancel()
 
+	p := &progress{start: time.Now()}
+	if len(options.watches) > 0 {
+		return p, forWatch(ctx, p, cond, options)
+	}
+
+	interval := options.Interval
+	if !options.Immediate {
+		select {
+		case <-ctx.Done():
+		case <-time.After(options.withJitter(interval)):
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			// Timeout / deadline reached
-			return ctx.Err()
+			return p, ctx.Err()
 		default:
-			done, err := fn()
+			done, err := p.evaluate(ctx, cond, options)
 			if err != nil {
-				return err
+				return p, err
 			}
-			if !done {
-				time.Sleep(options.Interval)
-				continue
+			if done {
+				return p, nil
 			}
+		}
 
-			return nil
+		select {
+		case <-ctx.Done():
+		case <-time.After(options.withJitter(interval)):
 		}
+		interval = options.nextInterval(interval)
+	}
+}
+
+// nextInterval returns the interval to use after the given one, applying any backoff
+func (o *Options) nextInterval(interval time.Duration) time.Duration {
+	if o.BackoffFactor <= 1 {
+		return interval
+	}
+
+	next := time.Duration(float64(interval) * o.BackoffFactor)
+	if o.MaxInterval > 0 && next > o.MaxInterval {
+		next = o.MaxInterval
+	}
+	return next
+}
+
+// withJitter returns the interval randomly increased by up to the jitter fraction of it
+func (o *Options) withJitter(interval time.Duration) time.Duration {
+	if o.Jitter <= 0 {
+		return interval
 	}
+	return interval + time.Duration(rand.Float64()*o.Jitter*float64(interval)) // #nosec G404
 }
@@ -0,0 +1,143 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+func TestNextInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  Options
+		interval time.Duration
+		expected time.Duration
+	}{
+		{name: "fixed", options: Options{}, interval: time.Second, expected: time.Second},
+		{name: "backoff", options: Options{BackoffFactor: 2}, interval: time.Second, expected: 2 * time.Second},
+		{name: "capped", options: Options{BackoffFactor: 2, MaxInterval: 3 * time.Second}, interval: 2 * time.Second, expected: 3 * time.Second},
+		{name: "factor below 1 ignored", options: Options{BackoffFactor: 0.5}, interval: time.Second, expected: time.Second},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.options.nextInterval(tc.interval); actual != tc.expected {
+				t.Errorf("Interval not as expected. Expected: %s, Actual: %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	options := &Options{Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		actual := options.withJitter(time.Second)
+		if actual < time.Second || actual > 1500*time.Millisecond {
+			t.Fatalf("Expected jitter to add up to 50%%. Actual: %s", actual)
+		}
+	}
+
+	if actual := (&Options{}).withJitter(time.Second); actual != time.Second {
+		t.Errorf("Expected no jitter by default. Actual: %s", actual)
+	}
+}
+
+func TestFor_Backoff(t *testing.T) {
+	var checks []time.Time
+	err := For(func() (bool, error) {
+		checks = append(checks, time.Now())
+		return len(checks) == 4, nil
+	}, WithInterval(10*time.Millisecond), WithBackoff(2, 25*time.Millisecond), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	// Intervals of 10ms, 20ms then capped at 25ms
+	if elapsed := checks[3].Sub(checks[0]); elapsed < 55*time.Millisecond {
+		t.Errorf("Expected the interval to back off. Elapsed: %s", elapsed)
+	}
+}
+
+func TestFor_Immediate(t *testing.T) {
+	start := time.Now()
+	var firstCheck time.Duration
+	condition := func() (bool, error) {
+		firstCheck = time.Since(start)
+		return true, nil
+	}
+
+	if err := For(condition, WithInterval(100*time.Millisecond)); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if firstCheck >= 100*time.Millisecond {
+		t.Errorf("Expected the condition to be checked straight away. First check after: %s", firstCheck)
+	}
+
+	start = time.Now()
+	if err := For(condition, WithInterval(100*time.Millisecond), WithImmediate(false)); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if firstCheck < 100*time.Millisecond {
+		t.Errorf("Expected the first check to wait for the interval. First check after: %s", firstCheck)
+	}
+}
+
+func TestForCondition_AttemptTimeout(t *testing.T) {
+	attempts := 0
+	cond := func(ctx context.Context) (bool, string, error) {
+		attempts++
+		if attempts == 1 {
+			// Simulate a hanging request
+			<-ctx.Done()
+			return false, "", ctx.Err()
+		}
+		return true, "", nil
+	}
+
+	err := ForCondition(cond, WithAttemptTimeout(20*time.Millisecond), WithInterval(time.Millisecond), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("Expected an attempt timing out to not stop waiting - %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts. Actual: %d", attempts)
+	}
+
+	// Other errors are still returned
+	expectedErr := errors.New("failed")
+	err = ForCondition(func(_ context.Context) (bool, string, error) {
+		return false, "", expectedErr
+	}, WithAttemptTimeout(time.Second), WithTimeout(5*time.Second))
+	if !errors.Is(err, expectedErr) {
+		t.Errorf("Expected the condition error to be returned. Actual: %v", err)
+	}
+}
+
+func TestFor_LogProgressEvery(t *testing.T) {
+	out := &bytes.Buffer{}
+	originalWriter := logger.LogWriter
+	logger.LogWriter = out
+	defer func() { logger.LogWriter = originalWriter }()
+
+	attempts := 0
+	cond := func(_ context.Context) (bool, string, error) {
+		attempts++
+		return attempts == 5, "waiting on replicas", nil
+	}
+
+	err := ForCondition(cond, WithInterval(time.Millisecond), WithLogProgressEvery(2), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if actual := strings.Count(out.String(), "Still waiting after"); actual != 2 {
+		t.Errorf("Expected progress to be logged every 2 attempts. Actual: %d times\n%s", actual, out)
+	}
+	if !strings.Contains(out.String(), "waiting on replicas") {
+		t.Errorf("Expected the progress to include the reason. Actual: %s", out)
+	}
+}
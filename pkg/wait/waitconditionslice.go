@@ -10,6 +10,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/giantswarm/clustertest/v5/pkg/client"
 	"github.com/giantswarm/clustertest/v5/pkg/logger"
@@ -81,17 +82,22 @@ func AreAllAppStatusSlice(ctx context.Context, kubeClient *client.Client, appNam
 }
 
 // AreAllDeploymentsReadySlice returns a WaitConditionSlice that checks if all Deployments found in the cluster have the expected number of replicas ready
-func AreAllDeploymentsReadySlice(ctx context.Context, kubeClient *client.Client) WaitConditionSlice {
+//
+// Accepts the same list options as `AreAllDeploymentsReady`.
+func AreAllDeploymentsReadySlice(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitConditionSlice {
 	return func() ([]any, error) {
 		failingDeployments := []any{}
 
 		deploymentList := &appsv1.DeploymentList{}
-		err := kubeClient.List(ctx, deploymentList)
+		err := kubeClient.List(ctx, deploymentList, listOptions...)
 		if err != nil {
 			return failingDeployments, err
 		}
 
 		for _, deployment := range deploymentList.Items {
+			if isExcluded(&deployment, listOptions) {
+				continue
+			}
 			available := deployment.Status.AvailableReplicas
 			desired := *deployment.Spec.Replicas
 			if available != desired {
@@ -109,17 +115,22 @@ func AreAllDeploymentsReadySlice(ctx context.Context, kubeClient *client.Client)
 }
 
 // AreAllStatefulSetsReadySlice returns a WaitConditionSlice that checks if all StatefulSets found in the cluster have the expected number of replicas ready
-func AreAllStatefulSetsReadySlice(ctx context.Context, kubeClient *client.Client) WaitConditionSlice {
+//
+// Accepts the same list options as `AreAllStatefulSetsReady`.
+func AreAllStatefulSetsReadySlice(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitConditionSlice {
 	return func() ([]any, error) {
 		failingStatefulSets := []any{}
 
 		statefulSetList := &appsv1.StatefulSetList{}
-		err := kubeClient.List(ctx, statefulSetList)
+		err := kubeClient.List(ctx, statefulSetList, listOptions...)
 		if err != nil {
 			return failingStatefulSets, err
 		}
 
 		for _, statefulSet := range statefulSetList.Items {
+			if isExcluded(&statefulSet, listOptions) {
+				continue
+			}
 			available := statefulSet.Status.AvailableReplicas
 			desired := *statefulSet.Spec.Replicas
 			if available != desired {
@@ -137,17 +148,22 @@ func AreAllStatefulSetsReadySlice(ctx context.Context, kubeClient *client.Client
 }
 
 // AreAllDaemonSetsReadySlice returns a WaitConditionSlice that checks if all DaemonSets found in the cluster have the expected number of replicas ready
-func AreAllDaemonSetsReadySlice(ctx context.Context, kubeClient *client.Client) WaitConditionSlice {
+//
+// Accepts the same list options as `AreAllDaemonSetsReady`.
+func AreAllDaemonSetsReadySlice(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitConditionSlice {
 	return func() ([]any, error) {
 		failingDaemonSets := []any{}
 
 		daemonSetList := &appsv1.DaemonSetList{}
-		err := kubeClient.List(ctx, daemonSetList)
+		err := kubeClient.List(ctx, daemonSetList, listOptions...)
 		if err != nil {
 			return failingDaemonSets, err
 		}
 
 		for _, daemonSet := range daemonSetList.Items {
+			if isExcluded(&daemonSet, listOptions) {
+				continue
+			}
 			current := daemonSet.Status.CurrentNumberScheduled
 			desired := daemonSet.Status.DesiredNumberScheduled
 			if current != desired {
@@ -165,17 +181,22 @@ func AreAllDaemonSetsReadySlice(ctx context.Context, kubeClient *client.Client)
 }
 
 // AreAllJobsSucceededSlice returns a WaitConditionSlice that checks if all Jobs found in the cluster have completed successfully
-func AreAllJobsSucceededSlice(ctx context.Context, kubeClient *client.Client) WaitConditionSlice {
+//
+// Accepts the same list options as `AreAllJobsSucceeded`.
+func AreAllJobsSucceededSlice(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitConditionSlice {
 	return func() ([]any, error) {
 		failingJobs := []any{}
 
 		jobList := &batchv1.JobList{}
-		err := kubeClient.List(ctx, jobList)
+		err := kubeClient.List(ctx, jobList, listOptions...)
 		if err != nil {
 			return failingJobs, err
 		}
 
 		for _, job := range jobList.Items {
+			if isExcluded(&job, listOptions) {
+				continue
+			}
 			if job.Status.Succeeded == 0 && job.Status.Active == 0 {
 				logger.Log("Job %s/%s has not succeeded. (Failed: '%d')", job.Namespace, job.Name, job.Status.Failed)
 				failingJobs = append(failingJobs, fmt.Sprintf("%s/%s", job.Namespace, job.Name))
@@ -197,17 +218,22 @@ func AreAllJobsSucceededSlice(ctx context.Context, kubeClient *client.Client) Wa
 }
 
 // AreAllPodsInSuccessfulPhaseSlice returns a WaitConditionSlice that checks if all Pods found in the cluster are in a successful phase (e.g. running or completed)
-func AreAllPodsInSuccessfulPhaseSlice(ctx context.Context, kubeClient *client.Client) WaitConditionSlice {
+//
+// Accepts the same list options as `AreAllPodsInSuccessfulPhase`.
+func AreAllPodsInSuccessfulPhaseSlice(ctx context.Context, kubeClient *client.Client, listOptions ...cr.ListOption) WaitConditionSlice {
 	return func() ([]any, error) {
 		failingPods := []any{}
 
 		podList := &corev1.PodList{}
-		err := kubeClient.List(ctx, podList)
+		err := kubeClient.List(ctx, podList, listOptions...)
 		if err != nil {
 			return failingPods, err
 		}
 
 		for _, pod := range podList.Items {
+			if isExcluded(&pod, listOptions) {
+				continue
+			}
 			phase := pod.Status.Phase
 			if phase != corev1.PodRunning && phase != corev1.PodSucceeded {
 				failingPods = append(failingPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
@@ -0,0 +1,221 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+)
+
+const (
+	// DefaultResyncInterval is the interval a WaitCondition is re-evaluated at when watching, if no change events
+	// have been received, in case a change has been missed.
+	DefaultResyncInterval = 1 * time.Minute
+	// watchRestartDelay is how long to wait before re-establishing a watch closed by the API server
+	watchRestartDelay = 1 * time.Second
+)
+
+// Watcher is a client able to watch for changes to objects, such as `client.Client`
+type Watcher interface {
+	Watch(ctx context.Context, list cr.ObjectList, opts ...cr.ListOption) (watch.Interface, error)
+}
+
+// watchSource is a kind of object that, when changed, triggers the WaitCondition to be re-evaluated
+type watchSource struct {
+	watcher     Watcher
+	list        cr.ObjectList
+	listOptions []cr.ListOption
+}
+
+// WithWatch switches `For` from polling to a watch-based mode where the WaitCondition is only evaluated when the
+// objects of the kind of the provided list change (e.g. `&appsv1.DeploymentList{}` for Deployments), filtered by
+// the provided list options. This greatly reduces the number of requests made to the API server while waiting.
+//
+// The WaitCondition is evaluated at most once per interval (see `WithInterval`) so that bursts of changes only result
+// in a single evaluation. As a fallback it is also evaluated periodically (see `WithResyncInterval`) in case changes
+// are missed, for example after the watch fails. Provide this option multiple times to watch multiple kinds.
+//
+// Example:
+//
+//	err := wait.For(
+//		wait.AreAllDeploymentsReady(ctx, wcClient),
+//		wait.WithWatch(wcClient, &appsv1.DeploymentList{}),
+//		wait.WithTimeout(10*time.Minute),
+//	)
+func WithWatch(watcher Watcher, list cr.ObjectList, listOptions ...cr.ListOption) Option {
+	return func(options *Options) {
+		options.watches = append(options.watches, watchSource{
+			watcher:     watcher,
+			list:        list,
+			listOptions: listOptions,
+		})
+	}
+}
+
+// WithResyncInterval overrides the default interval the WaitCondition is evaluated at when watching if no change
+// events have been received. Only used along with `WithWatch`.
+func WithResyncInterval(interval time.Duration) Option {
+	return func(options *Options) {
+		options.ResyncInterval = interval
+	}
+}
+
+// forWatch evaluates the Condition initially and then each time a change is seen by one of the watches, or the
+// resync interval passes without changes, until either the context is done or the function returns as done
+func forWatch(ctx context.Context, p *progress, cond Condition, options *Options) error {
+	resyncInterval := options.ResyncInterval
+	if resyncInterval <= 0 {
+		resyncInterval = DefaultResyncInterval
+	}
+	minInterval := options.Interval
+	if minInterval <= 0 {
+		minInterval = DefaultInterval
+	}
+
+	// Buffered so that multiple change events received during an evaluation only result in a single re-evaluation
+	changed := make(chan struct{}, 1)
+	for _, source := range options.watches {
+		go source.run(ctx, changed, resyncInterval)
+	}
+
+	resync := time.NewTimer(resyncInterval)
+	defer resync.Stop()
+
+	waitForChange := func() error {
+		select {
+		case <-ctx.Done():
+			// Timeout / deadline reached
+			return ctx.Err()
+		case <-changed:
+		case <-resync.C:
+		}
+
+		if !resync.Stop() {
+			select {
+			case <-resync.C:
+			default:
+			}
+		}
+		resync.Reset(resyncInterval)
+		return nil
+	}
+
+	if !options.Immediate {
+		if err := waitForChange(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		done, err := p.evaluate(ctx, cond, options)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		// Avoid re-evaluating too often when there's lots of changes happening
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(minInterval):
+		}
+
+		if err := waitForChange(); err != nil {
+			return err
+		}
+	}
+}
+
+// run watches for changes to the objects, notifying the `changed` channel for each, until the context is done.
+// If the watch is closed by the API server it is resumed from the last seen resourceVersion. If the watch fails, or
+// can't be resumed, it is re-established from the current state instead and a change is notified as changes may
+// have been missed.
+func (s watchSource) run(ctx context.Context, changed chan<- struct{}, retryInterval time.Duration) {
+	notify := func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+
+	resourceVersion := ""
+	for {
+		var err error
+		resourceVersion, err = s.watch(ctx, resourceVersion, notify)
+		if ctx.Err() != nil {
+			return
+		}
+
+		retryAfter := watchRestartDelay
+		if err != nil {
+			logger.Log("Failed watching %T, retrying in %s - %v", s.list, retryInterval, err)
+			retryAfter = retryInterval
+			// The resourceVersion may be too old to resume from
+			resourceVersion = ""
+		}
+		if resourceVersion == "" {
+			// Changes may have been missed while the watch wasn't running
+			notify()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryAfter):
+		}
+	}
+}
+
+// watch starts a single watch, from the given resourceVersion if not empty, and calls `notify` for each change event
+// until the watch is closed. The last resourceVersion seen is returned so the watch can be resumed.
+func (s watchSource) watch(ctx context.Context, resourceVersion string, notify func()) (string, error) {
+	listOptions := append(append([]cr.ListOption{}, s.listOptions...), resumeWatch(resourceVersion))
+	watcher, err := s.watcher.Watch(ctx, s.list, listOptions...)
+	if err != nil {
+		return resourceVersion, err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion, nil
+			}
+			if event.Type == watch.Error {
+				return resourceVersion, fmt.Errorf("watch error - %v", event.Object)
+			}
+			if obj, err := meta.Accessor(event.Object); err == nil && obj.GetResourceVersion() != "" {
+				resourceVersion = obj.GetResourceVersion()
+			}
+			if event.Type != watch.Bookmark {
+				notify()
+			}
+		}
+	}
+}
+
+// resumeWatch is a list option that starts a watch from the given resourceVersion, if not empty, and requests
+// bookmark events so the latest resourceVersion is known even when nothing changes
+type resumeWatch string
+
+// ApplyToList sets the resourceVersion to watch from and enables bookmarks
+func (r resumeWatch) ApplyToList(opts *cr.ListOptions) {
+	if opts.Raw == nil {
+		opts.Raw = &metav1.ListOptions{}
+	}
+	opts.Raw.AllowWatchBookmarks = true
+	if r != "" {
+		opts.Raw.ResourceVersion = string(r)
+	}
+}
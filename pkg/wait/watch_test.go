@@ -0,0 +1,156 @@
+package wait
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestForWithWatch(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewClientBuilder().Build()
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+
+	var evaluations atomic.Int32
+	exists := func() (bool, error) {
+		evaluations.Add(1)
+		err := kubeClient.Get(ctx, cr.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = kubeClient.Create(ctx, configMap.DeepCopy())
+	}()
+
+	start := time.Now()
+	err := For(exists,
+		WithWatch(kubeClient, &corev1.ConfigMapList{}, cr.InNamespace("default")),
+		WithInterval(100*time.Millisecond),
+		WithResyncInterval(time.Hour),
+		WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the condition to be evaluated when the ConfigMap was created. Took: %s", elapsed)
+	}
+	if actual := evaluations.Load(); actual > 3 {
+		t.Errorf("Expected the condition to only be evaluated on changes. Evaluations: %d", actual)
+	}
+}
+
+func TestForWithWatch_Resync(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+
+	var evaluations atomic.Int32
+	condition := func() (bool, error) {
+		return evaluations.Add(1) >= 2, nil
+	}
+
+	// No changes occur so only the resync re-evaluates the condition
+	err := For(condition,
+		WithWatch(kubeClient, &corev1.ConfigMapList{}),
+		WithInterval(100*time.Millisecond),
+		WithResyncInterval(1500*time.Millisecond),
+		WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+}
+
+func TestForWithWatch_Timeout(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+
+	err := For(func() (bool, error) { return false, nil },
+		WithWatch(kubeClient, &corev1.ConfigMapList{}),
+		WithTimeout(500*time.Millisecond),
+	)
+	if err == nil {
+		t.Errorf("Expected an error to be returned")
+	}
+}
+
+// fakeWatcher hands out a fake watch for each call to Watch, recording the resourceVersion it was started from
+type fakeWatcher struct {
+	watches          chan *watch.FakeWatcher
+	resourceVersions chan string
+}
+
+func (w *fakeWatcher) Watch(_ context.Context, _ cr.ObjectList, opts ...cr.ListOption) (watch.Interface, error) {
+	listOptions := (&cr.ListOptions{}).ApplyOptions(opts)
+	w.resourceVersions <- listOptions.Raw.ResourceVersion
+	fw := watch.NewFake()
+	w.watches <- fw
+	return fw, nil
+}
+
+func TestForWithWatch_Resume(t *testing.T) {
+	watcher := &fakeWatcher{watches: make(chan *watch.FakeWatcher, 3), resourceVersions: make(chan string, 3)}
+
+	var evaluations atomic.Int32
+	var finished atomic.Bool
+	condition := func() (bool, error) {
+		evaluations.Add(1)
+		return finished.Load(), nil
+	}
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- For(condition,
+			WithWatch(watcher, &corev1.ConfigMapList{}),
+			WithInterval(10*time.Millisecond),
+			WithResyncInterval(time.Hour),
+			WithTimeout(10*time.Second),
+		)
+	}()
+
+	waitForEvaluations := func(expected int32) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for evaluations.Load() < expected && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if actual := evaluations.Load(); actual != expected {
+			t.Fatalf("Expected %d evaluations. Actual: %d", expected, actual)
+		}
+	}
+
+	if rv := <-watcher.resourceVersions; rv != "" {
+		t.Errorf("Expected the first watch to start from the current state. Actual resourceVersion: %s", rv)
+	}
+	first := <-watcher.watches
+	waitForEvaluations(1)
+	first.Modify(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "example", ResourceVersion: "5"}})
+	waitForEvaluations(2)
+
+	// Closed by the API server so the watch is resumed without re-evaluating the condition
+	first.Stop()
+	if rv := <-watcher.resourceVersions; rv != "5" {
+		t.Errorf("Expected the watch to be resumed from the last resourceVersion. Actual resourceVersion: %s", rv)
+	}
+	second := <-watcher.watches
+	time.Sleep(200 * time.Millisecond)
+	waitForEvaluations(2)
+
+	// A failed watch may have missed changes so the condition is re-evaluated
+	finished.Store(true)
+	second.Error(&metav1.Status{Message: "too old resource version"})
+	if err := <-errCh; err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	waitForEvaluations(3)
+}
@@ -0,0 +1,294 @@
+package clustertest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+// State is a serializable snapshot of the clusters, organizations, releases and resources managed by a Framework.
+// It can be saved with `SaveState` and loaded again, possibly by a different process, with `LoadState`.
+type State struct {
+	// Clusters are the Workload Clusters applied or loaded by the Framework
+	Clusters []ClusterState `json:"clusters,omitempty"`
+	// Organizations are the Organizations created by the Framework
+	Organizations []*organization.Org `json:"organizations,omitempty"`
+	// Resources are the resources recorded by resource tracking, in creation order
+	Resources []ResourceState `json:"resources,omitempty"`
+}
+
+// ClusterState is a Workload Cluster known to the Framework
+type ClusterState struct {
+	// Cluster is the full Cluster, including its Release and any app overrides
+	Cluster *application.Cluster `json:"cluster"`
+	// ReleaseName is the name of the Release CR applied for the cluster, if any
+	ReleaseName string `json:"releaseName,omitempty"`
+}
+
+// ResourceState identifies a resource recorded by resource tracking (see `EnableResourceTracking`)
+type ResourceState struct {
+	// Cluster is the name of the Workload Cluster the resource was created in, empty for the Management Cluster
+	Cluster    string `json:"cluster,omitempty"`
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// WorkloadCluster indicates the resource is a Cluster applied with `ApplyCluster` and is removed with `DeleteCluster`
+	WorkloadCluster bool `json:"workloadCluster,omitempty"`
+}
+
+// stateRegistry keeps track of the clusters and organizations managed by a Framework
+type stateRegistry struct {
+	mu       sync.RWMutex
+	clusters []ClusterState
+	orgs     []*organization.Org
+}
+
+func (r *stateRegistry) addCluster(clusterState ClusterState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.clusters {
+		if existing.Cluster.Name == clusterState.Cluster.Name {
+			r.clusters[i] = clusterState
+			return
+		}
+	}
+	r.clusters = append(r.clusters, clusterState)
+}
+
+func (r *stateRegistry) removeCluster(clusterName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.clusters {
+		if existing.Cluster.Name == clusterName {
+			r.clusters = append(r.clusters[:i], r.clusters[i+1:]...)
+			return
+		}
+	}
+}
+
+// setClusterRelease updates the name of the Release CR used by a known cluster, e.g. after it has been upgraded
+func (r *stateRegistry) setClusterRelease(clusterName string, releaseName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.clusters {
+		if existing.Cluster.Name == clusterName {
+			r.clusters[i].ReleaseName = releaseName
+			return
+		}
+	}
+}
+
+func (r *stateRegistry) getCluster(clusterName string) *application.Cluster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, existing := range r.clusters {
+		if existing.Cluster.Name == clusterName {
+			return existing.Cluster
+		}
+	}
+	return nil
+}
+
+func (r *stateRegistry) addOrg(org *organization.Org) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.orgs {
+		if existing.Name == org.Name {
+			return
+		}
+	}
+	r.orgs = append(r.orgs, org)
+}
+
+func (r *stateRegistry) removeOrg(orgName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.orgs {
+		if existing.Name == orgName {
+			r.orgs = append(r.orgs[:i], r.orgs[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetState returns a snapshot of the clusters, organizations and releases currently managed by the Framework along with
+// any resources recorded by resource tracking
+func (f *Framework) GetState() *State {
+	state := &State{}
+
+	f.registry.mu.RLock()
+	state.Clusters = append(state.Clusters, f.registry.clusters...)
+	state.Organizations = append(state.Organizations, f.registry.orgs...)
+	f.registry.mu.RUnlock()
+
+	if f.tracker != nil {
+		f.tracker.mu.Lock()
+		for _, resource := range f.tracker.resources {
+			if resource.state == nil {
+				logger.Log("Unable to save %s to state", resource.description)
+				continue
+			}
+			state.Resources = append(state.Resources, *resource.state)
+		}
+		f.tracker.mu.Unlock()
+	}
+
+	return state
+}
+
+// SaveState writes the current state of the Framework (see `GetState`) to the file at the given path so that it can be
+// restored later, such as by a separate process, with `LoadState`
+//
+// Example:
+//
+//	err := framework.SaveState("/tmp/clustertest-state.json")
+func (f *Framework) SaveState(path string) error {
+	data, err := json.MarshalIndent(f.GetState(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadState restores the state of the Framework previously saved with `SaveState`.
+//
+// The full Cluster of each saved Workload Cluster is restored and a client for it is set up and made available via `WC`.
+// If resource tracking is enabled (see `EnableResourceTracking`) the saved resources are tracked again so they are
+// removed by `Cleanup`.
+//
+// The restored clusters are returned in the order they were saved.
+//
+// Example:
+//
+//	clusters, err := framework.LoadState(ctx, "/tmp/clustertest-state.json")
+//	if err != nil {
+//		// handle error
+//	}
+//	defer framework.DeleteCluster(ctx, clusters[0])
+func (f *Framework) LoadState(ctx context.Context, path string) ([]*application.Cluster, error) {
+	data, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file '%s': %w", path, err)
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file '%s': %w", path, err)
+	}
+
+	for _, org := range state.Organizations {
+		f.registry.addOrg(org)
+	}
+
+	clusters := []*application.Cluster{}
+	for _, clusterState := range state.Clusters {
+		if clusterState.Cluster == nil {
+			continue
+		}
+		cluster := clusterState.Cluster
+
+		logger.Log("Restoring client for cluster '%s'", cluster.Name)
+		kubeconfig, err := f.MC().GetClusterKubeConfig(ctx, cluster.Name, cluster.GetNamespace())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubeconfig for cluster '%s': %w", cluster.Name, err)
+		}
+
+		kubeClient, err := client.NewFromRawKubeconfigWithOptions([]byte(kubeconfig), f.clientOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for cluster '%s': %w", cluster.Name, err)
+		}
+
+		testClient, err := f.testUserClient(ctx, kubeClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create test user for cluster '%s': %w", cluster.Name, err)
+		}
+		if testClient == kubeClient {
+			f.enableCredentialRefresh(testClient, cluster.Name, cluster.GetNamespace())
+		}
+
+		f.recordOrgEvents(cluster.GetNamespace())
+		f.setWC(cluster.Name, testClient)
+		f.registry.addCluster(clusterState)
+		clusters = append(clusters, cluster)
+	}
+
+	if f.tracker != nil {
+		for _, resource := range state.Resources {
+			restored, err := f.restoreTrackedResource(resource)
+			if err != nil {
+				return nil, err
+			}
+			f.tracker.add(restored)
+		}
+	} else if len(state.Resources) > 0 {
+		logger.Log("Resource tracking isn't enabled, ignoring %d tracked resources in state file", len(state.Resources))
+	}
+
+	return clusters, nil
+}
+
+// restoreTrackedResource returns the trackedResource for a resource loaded from a saved state
+func (f *Framework) restoreTrackedResource(resource ResourceState) (trackedResource, error) {
+	resourceState := resource
+
+	if resource.WorkloadCluster {
+		return trackedResource{
+			description: fmt.Sprintf("Cluster '%s/%s'", resource.Namespace, resource.Name),
+			delete: func(ctx context.Context) error {
+				cluster := f.registry.getCluster(resourceState.Name)
+				if cluster == nil {
+					// Already deleted
+					return nil
+				}
+				return f.DeleteCluster(ctx, cluster)
+			},
+			state: &resourceState,
+		}, nil
+	}
+
+	c := f.MC()
+	if resource.Cluster != "" {
+		var err error
+		c, err = f.WC(resource.Cluster)
+		if err != nil {
+			return trackedResource{}, fmt.Errorf("failed to restore tracked %s '%s': %w", resource.Kind, resource.Name, err)
+		}
+	}
+
+	gvk := schema.FromAPIVersionAndKind(resource.APIVersion, resource.Kind)
+	return trackedResource{
+		description: describeResource(resource.Kind, resource.Namespace, resource.Name, resource.Cluster),
+		delete: func(ctx context.Context) error {
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(gvk)
+			obj.SetNamespace(resourceState.Namespace)
+			obj.SetName(resourceState.Name)
+
+			// Fetch the latest version first so we know if it is safe to delete
+			if err := c.Get(ctx, cr.ObjectKeyFromObject(obj), obj); err != nil {
+				return cr.IgnoreNotFound(err)
+			}
+
+			return deleteTrackedObject(ctx, c, obj, gvk)
+		},
+		cluster: resource.Cluster,
+		state:   &resourceState,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package clustertest
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
+)
+
+// teleportKubeconfig uses a cluster name different from the name of the cluster in the Framework
+const teleportKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: teleport.example.io-t-state
+  cluster:
+    server: https://127.0.0.1:6443
+    tls-server-name: kube-teleport.example.io
+contexts:
+- name: teleport.example.io-t-state
+  context:
+    cluster: teleport.example.io-t-state
+    user: e2e
+current-context: teleport.example.io-t-state
+users:
+- name: e2e
+  user:
+    token: abc
+`
+
+func TestSaveAndLoadState(t *testing.T) {
+	ctx := context.Background()
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	mcClient := fake.NewClientBuilder().Build()
+
+	org := organization.New("state")
+	cluster := application.NewClusterApp("t-state", application.ProviderAWS).WithOrg(org)
+
+	// Framework creating the cluster and resources
+	f := &Framework{
+		mcClient:  &client.Client{Client: mcClient},
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+	f.EnableResourceTracking()
+	f.registry.addOrg(org)
+	f.registry.addCluster(ClusterState{Cluster: cluster, ReleaseName: "aws-30.0.0-t-state"})
+	f.trackCluster(cluster)
+	f.setWC(cluster.Name, &client.Client{Client: fake.NewClientBuilder().Build()})
+
+	if err := f.MC().Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "mc-config", Namespace: "default"}}); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	wcClient, err := f.WC(cluster.Name)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	if err := wcClient.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "wc-config", Namespace: "default"}}); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if err := f.SaveState(statePath); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	// Fresh Framework, as if in another process
+	restored := &Framework{
+		mcClient:  &client.Client{Client: mcClient},
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+	restored.EnableResourceTracking()
+	restored.MC().SetKubeconfigProviders(client.KubeconfigProviderFunc(func(_ context.Context, _ *client.Client, clusterName string, _ string) (string, error) {
+		return teleportKubeconfig, nil
+	}))
+
+	clusters, err := restored.LoadState(ctx, statePath)
+	if err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if len(clusters) != 1 || clusters[0].Name != cluster.Name || clusters[0].Provider != application.ProviderAWS || clusters[0].GetNamespace() != org.GetNamespace() {
+		t.Fatalf("Restored clusters not as expected. Actual: %+v", clusters)
+	}
+	if !slices.Equal(restored.GetState().Clusters, []ClusterState{{Cluster: clusters[0], ReleaseName: "aws-30.0.0-t-state"}}) {
+		t.Errorf("Restored registry clusters not as expected. Actual: %+v", restored.GetState().Clusters)
+	}
+	if orgs := restored.GetState().Organizations; len(orgs) != 1 || orgs[0].Name != org.Name || orgs[0].GetNamespace() != org.GetNamespace() {
+		t.Errorf("Restored registry organizations not as expected. Actual: %+v", orgs)
+	}
+
+	restoredWC, err := restored.WC(cluster.Name)
+	if err != nil {
+		t.Fatalf("Expected a client to be restored for the cluster - %v", err)
+	}
+	if restoredWC.GetClusterName() == cluster.Name || !restoredWC.IsTeleportKubeconfig() {
+		t.Errorf("Expected the restored client to use the Teleport kubeconfig. Actual cluster name: %s", restoredWC.GetClusterName())
+	}
+
+	expectedResources := []ResourceState{
+		{Namespace: org.GetNamespace(), Name: cluster.Name, WorkloadCluster: true},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "mc-config"},
+		{Cluster: cluster.Name, APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "wc-config"},
+	}
+	if actual := restored.GetState().Resources; !slices.Equal(actual, expectedResources) {
+		t.Errorf("Restored tracked resources not as expected.\nExpected: %+v\nActual: %+v", expectedResources, actual)
+	}
+	if description := restored.tracker.resources[2].description; description != "ConfigMap 'default/wc-config' in cluster 't-state'" {
+		t.Errorf("Restored tracked resource description not as expected. Actual: %s", description)
+	}
+}
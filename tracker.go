@@ -0,0 +1,233 @@
+package clustertest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	orgv1alpha1 "github.com/giantswarm/organization-operator/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/utils"
+	"github.com/giantswarm/clustertest/v5/pkg/wait"
+)
+
+// trackedResource is a single resource recorded by the resourceTracker along with how to remove it again
+type trackedResource struct {
+	description string
+	delete      func(ctx context.Context) error
+	// cluster is the name of the Workload Cluster the resource was created in, empty for the MC or a Cluster itself
+	cluster string
+	// state identifies the resource when saving the Framework state. Resources without it aren't saved.
+	state *ResourceState
+}
+
+// resourceTracker records resources created via the Framework so they can be removed in reverse creation order
+type resourceTracker struct {
+	mu        sync.Mutex
+	resources []trackedResource
+}
+
+func (t *resourceTracker) add(resource trackedResource) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resources = append(t.resources, resource)
+}
+
+// EnableResourceTracking configures the Framework to record every object created through its MC and WC clients
+// (including via `CreateOrUpdate`, `DeployApp` and `ApplyManifests`) as well as every Cluster applied with
+// `ApplyCluster`. All recorded resources can then be removed in reverse creation order by calling `Cleanup`.
+//
+// Only resources created after calling this function are recorded.
+//
+// Example:
+//
+//	framework, err := clustertest.New("context_name")
+//	if err != nil {
+//		panic(err)
+//	}
+//	framework.EnableResourceTracking()
+//
+//	// Create clusters and resources...
+//
+//	err = framework.Cleanup(ctx)
+func (f *Framework) EnableResourceTracking() {
+	f.wcClientsMu.Lock()
+	defer f.wcClientsMu.Unlock()
+
+	if f.tracker != nil {
+		return
+	}
+	f.tracker = &resourceTracker{}
+
+	f.mcClient.AddCreateHook(f.trackObjectHook(""))
+	for clusterName, wcClient := range f.wcClients {
+		wcClient.AddCreateHook(f.trackObjectHook(clusterName))
+	}
+}
+
+// trackObjectHook returns a client.CreateHook that records objects created in the given Workload Cluster, or the MC if
+// empty, with the resource tracker. The cluster name is that used to look up the client with `WC`, which may differ
+// from the cluster name found in the client's kubeconfig.
+func (f *Framework) trackObjectHook(clusterName string) client.CreateHook {
+	return func(c *client.Client, obj cr.Object) {
+		f.trackObject(c, clusterName, obj)
+	}
+}
+
+// trackObject records the object created with the provided client with the resource tracker
+func (f *Framework) trackObject(c *client.Client, clusterName string, obj cr.Object) {
+	// Take a copy so later changes made by the caller don't affect what we delete
+	tracked, ok := obj.DeepCopyObject().(cr.Object)
+	if !ok {
+		return
+	}
+
+	gvk, err := apiutil.GVKForObject(tracked, c.Scheme())
+	if err != nil {
+		f.tracker.add(trackedResource{
+			description: describeResource("resource", tracked.GetNamespace(), tracked.GetName(), clusterName),
+			delete: func(ctx context.Context) error {
+				return deleteTrackedObject(ctx, c, tracked, schema.GroupVersionKind{})
+			},
+			cluster: clusterName,
+		})
+		return
+	}
+
+	description := describeResource(gvk.Kind, tracked.GetNamespace(), tracked.GetName(), clusterName)
+	f.tracker.add(trackedResource{
+		description: description,
+		delete: func(ctx context.Context) error {
+			return deleteTrackedObject(ctx, c, tracked, gvk)
+		},
+		cluster: clusterName,
+		state: &ResourceState{
+			Cluster:    clusterName,
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  tracked.GetNamespace(),
+			Name:       tracked.GetName(),
+		},
+	})
+}
+
+// describeResource returns a human-friendly description of a tracked resource
+func describeResource(kind string, namespace string, name string, clusterName string) string {
+	description := fmt.Sprintf("%s '%s'", kind, name)
+	if namespace != "" {
+		description = fmt.Sprintf("%s '%s/%s'", kind, namespace, name)
+	}
+	if clusterName != "" {
+		description = fmt.Sprintf("%s in cluster '%s'", description, clusterName)
+	}
+	return description
+}
+
+// deleteTrackedObject deletes the object and waits for it to be removed. Organizations and Releases are only removed if
+// they were created specifically for testing.
+func deleteTrackedObject(ctx context.Context, c *client.Client, obj cr.Object, gvk schema.GroupVersionKind) error {
+	switch gvk.GroupKind() {
+	case orgv1alpha1.SchemeGroupVersion.WithKind("Organization").GroupKind(), releases.SchemeGroupVersion.WithKind("Release").GroupKind():
+		// Only remove shared resources if they were created specifically for testing
+		if !utils.SafeToDelete(obj.GetAnnotations()) {
+			logger.Log("Skipping deletion of %s '%s' as it isn't marked as safe to delete", gvk.Kind, obj.GetName())
+			return nil
+		}
+	}
+
+	err := c.Delete(ctx, obj)
+	if cr.IgnoreNotFound(err) != nil {
+		return err
+	} else if err != nil {
+		// Already gone
+		return nil
+	}
+
+	return wait.For(wait.IsResourceDeleted(ctx, c, obj), wait.WithContext(ctx))
+}
+
+// trackCluster records an applied Cluster with the resource tracker, if enabled, so that it is removed using `DeleteCluster`
+func (f *Framework) trackCluster(cluster *application.Cluster) {
+	if f.tracker == nil {
+		return
+	}
+
+	f.tracker.add(trackedResource{
+		description: fmt.Sprintf("Cluster '%s/%s'", cluster.GetNamespace(), cluster.Name),
+		delete: func(ctx context.Context) error {
+			if f.registry.getCluster(cluster.Name) == nil {
+				// Already deleted, e.g. by the test calling `DeleteCluster` itself
+				return nil
+			}
+			return f.DeleteCluster(ctx, cluster)
+		},
+		state: &ResourceState{
+			Namespace:       cluster.GetNamespace(),
+			Name:            cluster.Name,
+			WorkloadCluster: true,
+		},
+	})
+}
+
+// Cleanup removes all resources recorded since `EnableResourceTracking` was called, in reverse order of their creation,
+// waiting for each to be fully deleted before moving on to the next.
+//
+// Organizations and Releases are only removed if they are marked as safe to delete and nothing is removed if the
+// `E2E_WC_KEEP` env var is set.
+//
+// All resources are attempted even if some fail to be removed. An error listing each resource that couldn't be removed
+// is returned and those resources remain tracked so `Cleanup` can be retried. Resources created in a Workload Cluster
+// that has since been deleted (e.g. earlier in the same `Cleanup` or with `DeleteCluster`) are no longer tracked as
+// they were removed along with the cluster.
+//
+// A timeout can be provided via the given `ctx` value by using `context.WithTimeout()`
+func (f *Framework) Cleanup(ctx context.Context) error {
+	if f.tracker == nil {
+		return fmt.Errorf("resource tracking has not been enabled")
+	}
+
+	if f.shouldKeepWorkloadCluster() {
+		logger.Log("⚠️ The %s env var is set, skipping cleanup of tracked resources", env.KeepWorkloadCluster)
+		return nil
+	}
+
+	f.tracker.mu.Lock()
+	resources := f.tracker.resources
+	f.tracker.resources = nil
+	f.tracker.mu.Unlock()
+
+	failed := []trackedResource{}
+	errs := []error{}
+	for i := len(resources) - 1; i >= 0; i-- {
+		resource := resources[i]
+		if resource.cluster != "" && f.registry.getCluster(resource.cluster) == nil {
+			logger.Log("Skipping cleanup of %s as the cluster has been deleted", resource.description)
+			continue
+		}
+
+		logger.Log("Cleaning up %s", resource.description)
+		if err := resource.delete(ctx); err != nil {
+			logger.Log("Failed to clean up %s - %v", resource.description, err)
+			// Prepend to keep the original creation order for any retry
+			failed = append([]trackedResource{resource}, failed...)
+			errs = append(errs, fmt.Errorf("failed to remove %s: %w", resource.description, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		f.tracker.mu.Lock()
+		f.tracker.resources = append(failed, f.tracker.resources...)
+		f.tracker.mu.Unlock()
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,135 @@
+package clustertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/env"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+	"github.com/giantswarm/clustertest/v5/pkg/timing"
+)
+
+func TestCleanup(t *testing.T) {
+	t.Setenv(env.KeepWorkloadCluster, "")
+	ctx := context.Background()
+
+	deleted := []string{}
+	failNext := map[string]bool{"second": true}
+	kubeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c cr.WithWatch, obj cr.Object, opts ...cr.DeleteOption) error {
+			if failNext[obj.GetName()] {
+				failNext[obj.GetName()] = false
+				return errors.New("failed")
+			}
+			deleted = append(deleted, obj.GetName())
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+
+	f := &Framework{
+		mcClient:  &client.Client{Client: kubeClient},
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+	f.EnableResourceTracking()
+
+	// Already removed from the registry, as if `DeleteCluster` had been called by the test
+	f.trackCluster(&application.Cluster{Name: "deleted", Organization: organization.New("test")})
+	for _, name := range []string{"first", "second", "third"} {
+		err := f.MC().Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+		if err != nil {
+			t.Fatalf("Not expecting an error to be returned - %v", err)
+		}
+	}
+
+	err := f.Cleanup(ctx)
+	if err == nil {
+		t.Fatalf("Expected an error to be returned for the failed resource")
+	}
+	if len(deleted) != 2 || deleted[0] != "third" || deleted[1] != "first" {
+		t.Errorf("Expected resources to be deleted in reverse order. Actual: %v", deleted)
+	}
+	if len(f.tracker.resources) != 1 || f.tracker.resources[0].description != "ConfigMap 'default/second'" {
+		t.Fatalf("Expected only the failed resource to remain tracked. Actual: %d resources", len(f.tracker.resources))
+	}
+
+	if err := f.Cleanup(ctx); err != nil {
+		t.Fatalf("Not expecting an error to be returned on retry - %v", err)
+	}
+	if len(deleted) != 3 || deleted[2] != "second" {
+		t.Errorf("Expected the failed resource to be deleted on retry. Actual: %v", deleted)
+	}
+	if len(f.tracker.resources) != 0 {
+		t.Errorf("Expected no resources to remain tracked. Actual: %d", len(f.tracker.resources))
+	}
+}
+
+func TestCleanup_DeletedCluster(t *testing.T) {
+	t.Setenv(env.KeepWorkloadCluster, "")
+	ctx := context.Background()
+
+	wcDeletes := 0
+	wcClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c cr.WithWatch, obj cr.Object, opts ...cr.DeleteOption) error {
+			wcDeletes++
+			return errors.New("workload cluster unreachable")
+		},
+	}).Build()
+
+	f := &Framework{
+		mcClient:  &client.Client{Client: fake.NewClientBuilder().Build()},
+		wcClients: map[string]*client.Client{},
+		timings:   timing.NewRecorder(),
+	}
+	f.EnableResourceTracking()
+
+	for _, clusterName := range []string{"t-first", "t-second"} {
+		f.registry.addCluster(ClusterState{Cluster: &application.Cluster{Name: clusterName, Organization: organization.New("test")}})
+		f.setWC(clusterName, &client.Client{Client: wcClient})
+	}
+
+	// Tracked before the resource deleting its cluster so the cluster is already gone when reached during cleanup
+	first, _ := f.WC("t-first")
+	if err := first.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default"}}); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+	f.tracker.add(trackedResource{
+		description: "Cluster 'org-test/t-first'",
+		delete: func(ctx context.Context) error {
+			f.registry.removeCluster("t-first")
+			return nil
+		},
+	})
+	second, _ := f.WC("t-second")
+	if err := second.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default"}}); err != nil {
+		t.Fatalf("Not expecting an error to be returned - %v", err)
+	}
+
+	if err := f.Cleanup(ctx); err == nil {
+		t.Fatalf("Expected an error to be returned for the resource in the unreachable cluster")
+	}
+	if wcDeletes != 1 {
+		t.Errorf("Expected resources in the deleted cluster to not be deleted. Actual deletes: %d", wcDeletes)
+	}
+	if len(f.tracker.resources) != 1 || f.tracker.resources[0].description != "ConfigMap 'default/second' in cluster 't-second'" {
+		t.Fatalf("Expected only the failed resource to remain tracked. Actual: %d resources", len(f.tracker.resources))
+	}
+
+	// Cluster deleted by the test before retrying
+	f.registry.removeCluster("t-second")
+	if err := f.Cleanup(ctx); err != nil {
+		t.Fatalf("Not expecting an error to be returned once the cluster is deleted - %v", err)
+	}
+	if wcDeletes != 1 || len(f.tracker.resources) != 0 {
+		t.Errorf("Expected the resource of the deleted cluster to no longer be tracked. Actual deletes: %d, resources: %d", wcDeletes, len(f.tracker.resources))
+	}
+}
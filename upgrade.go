@@ -0,0 +1,340 @@
+package clustertest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	cr "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/logger"
+	"github.com/giantswarm/clustertest/v5/pkg/wait"
+)
+
+const (
+	// DefaultUpgradeControlPlaneTimeout is the default max time to wait for the control plane to roll out during an upgrade
+	DefaultUpgradeControlPlaneTimeout = 30 * time.Minute
+	// DefaultUpgradeWorkersTimeout is the default max time to wait for the worker nodes to roll out during an upgrade
+	DefaultUpgradeWorkersTimeout = 30 * time.Minute
+	// DefaultUpgradeAppsTimeout is the default max time to wait for the default apps to be redeployed during an upgrade
+	DefaultUpgradeAppsTimeout = 15 * time.Minute
+)
+
+// UpgradeOptions are the options available when upgrading a cluster
+type UpgradeOptions struct {
+	ControlPlaneTimeout time.Duration
+	WorkersTimeout      time.Duration
+	AppsTimeout         time.Duration
+	Interval            time.Duration
+}
+
+// UpgradeOption is a function that can be optionally provided to override default options of an upgrade
+type UpgradeOption func(*UpgradeOptions)
+
+// WithControlPlaneTimeout overrides the max time to wait for the control plane to roll out.
+// A timeout of zero skips waiting for this phase.
+func WithControlPlaneTimeout(timeout time.Duration) UpgradeOption {
+	return func(options *UpgradeOptions) {
+		options.ControlPlaneTimeout = timeout
+	}
+}
+
+// WithWorkersTimeout overrides the max time to wait for the worker nodes to roll out.
+// A timeout of zero skips waiting for this phase.
+func WithWorkersTimeout(timeout time.Duration) UpgradeOption {
+	return func(options *UpgradeOptions) {
+		options.WorkersTimeout = timeout
+	}
+}
+
+// WithAppsTimeout overrides the max time to wait for the default apps to be redeployed.
+// A timeout of zero skips waiting for this phase.
+func WithAppsTimeout(timeout time.Duration) UpgradeOption {
+	return func(options *UpgradeOptions) {
+		options.AppsTimeout = timeout
+	}
+}
+
+// WithUpgradeInterval overrides the polling interval used while waiting on each phase
+func WithUpgradeInterval(interval time.Duration) UpgradeOption {
+	return func(options *UpgradeOptions) {
+		options.Interval = interval
+	}
+}
+
+// UpgradeCluster upgrades an existing Cluster to the provided Release.
+//
+// The target Release is built using the same logic as `Cluster.GetRelease` and applied to the MC, then the cluster
+// App is updated to the Release's cluster app version and `global.release.version` is set in the cluster values
+// ConfigMap. Once updated, `cluster.Release` and the Framework state (see `GetState`) refer to the new Release. The
+// upgrade then waits through the following phases, each with its own timeout:
+//
+//  1. The control plane has rolled out to the Release's Kubernetes version and is ready
+//  2. All worker nodes are running the Release's Kubernetes version and are ready
+//  3. All the Release's default apps have been redeployed at their new versions
+//
+// The provided `ctx` is used as an overall deadline for the upgrade. Clusters deployed as a Flux HelmRelease aren't
+// supported and an error is returned for them.
+//
+// Example:
+//
+//	from, to, err := utils.GetUpgradeReleasesToTest("aws")
+//
+//	cluster := application.NewClusterApp(utils.GenerateRandomName("t"), application.ProviderAWS).
+//		WithRelease(application.ReleasePair{Version: from})
+//	client, err := framework.ApplyCluster(ctx, cluster)
+//
+//	release, err := framework.UpgradeCluster(ctx, cluster, application.ReleasePair{Version: to},
+//		clustertest.WithWorkersTimeout(45*time.Minute),
+//	)
+func (f *Framework) UpgradeCluster(ctx context.Context, cluster *application.Cluster, toRelease application.ReleasePair, opts ...UpgradeOption) (*releases.Release, error) {
+	options := &UpgradeOptions{
+		ControlPlaneTimeout: DefaultUpgradeControlPlaneTimeout,
+		WorkersTimeout:      DefaultUpgradeWorkersTimeout,
+		AppsTimeout:         DefaultUpgradeAppsTimeout,
+		Interval:            30 * time.Second,
+	}
+	for _, optFn := range opts {
+		optFn(options)
+	}
+
+	if err := f.checkClusterIsApp(ctx, cluster); err != nil {
+		return nil, err
+	}
+
+	// Build the Release from a copy so the Cluster keeps its current Release until it has been updated
+	upgradedCluster := *cluster
+	upgradedCluster.WithRelease(toRelease)
+	f.applyClusterDefaults(&upgradedCluster)
+
+	release, err := upgradedCluster.GetRelease()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build release: %w", err)
+	}
+	logger.Log("Upgrading Cluster '%s' to Release '%s'", cluster.Name, release.Name)
+
+	if err := f.MC().CreateOrUpdate(ctx, release); err != nil {
+		return nil, fmt.Errorf("failed to apply release resources: %w", err)
+	}
+
+	if err := f.updateClusterRelease(ctx, cluster, release); err != nil {
+		return release, err
+	}
+	cluster.Release = upgradedCluster.Release
+
+	kubernetesVersion, err := release.GetKubernetesVersion()
+	if err != nil {
+		return release, err
+	}
+
+	phases := []struct {
+		name      string
+		timeout   time.Duration
+		condition func(context.Context) (wait.WaitCondition, error)
+	}{
+		{
+			name:    "control plane rollout",
+			timeout: options.ControlPlaneTimeout,
+			condition: func(ctx context.Context) (wait.WaitCondition, error) {
+				return f.isControlPlaneUpgraded(ctx, cluster, kubernetesVersion), nil
+			},
+		},
+		{
+			name:    "worker rollout",
+			timeout: options.WorkersTimeout,
+			condition: func(ctx context.Context) (wait.WaitCondition, error) {
+				wcClient, err := f.WC(cluster.Name)
+				if err != nil {
+					return nil, err
+				}
+				return areAllWorkersUpgraded(ctx, wcClient, kubernetesVersion), nil
+			},
+		},
+		{
+			name:    "default apps redeployed",
+			timeout: options.AppsTimeout,
+			condition: func(ctx context.Context) (wait.WaitCondition, error) {
+				return f.areDefaultAppsUpgraded(ctx, cluster, release), nil
+			},
+		},
+	}
+
+	for _, phase := range phases {
+		if phase.timeout == 0 {
+			logger.Log("Skipping upgrade phase '%s'", phase.name)
+			continue
+		}
+
+		logger.Log("Waiting for upgrade phase '%s' (timeout: %s)", phase.name, phase.timeout)
+		phaseCtx, cancel := context.WithTimeout(ctx, phase.timeout)
+		condition, err := phase.condition(phaseCtx)
+		if err == nil {
+			err = wait.For(condition, wait.WithContext(phaseCtx), wait.WithInterval(options.Interval))
+		}
+		cancel()
+		if err != nil {
+			return release, fmt.Errorf("upgrade phase '%s' failed: %w", phase.name, err)
+		}
+		logger.Log("Upgrade phase '%s' completed", phase.name)
+	}
+
+	return release, nil
+}
+
+// updateClusterRelease points the cluster App and its values ConfigMap at the provided Release and records the new
+// Release name for the cluster in the Framework state
+func (f *Framework) updateClusterRelease(ctx context.Context, cluster *application.Cluster, release *releases.Release) error {
+	releaseVersion, err := release.GetVersion()
+	if err != nil {
+		return err
+	}
+
+	clusterApp, clusterValues, err := f.GetAppAndValues(ctx, cluster.Name, cluster.GetNamespace())
+	if err != nil {
+		return fmt.Errorf("failed to get cluster app: %w", err)
+	}
+
+	values, err := application.SetReleaseVersionValue(clusterValues.Data["values"], releaseVersion)
+	if err != nil {
+		return fmt.Errorf("failed to set release version in cluster values: %w", err)
+	}
+	patchedValues := clusterValues.DeepCopy()
+	patchedValues.Data["values"] = values
+	if err := f.MC().Patch(ctx, patchedValues, cr.MergeFrom(clusterValues)); err != nil {
+		return fmt.Errorf("failed to update cluster values: %w", err)
+	}
+	if cluster.ClusterApp != nil {
+		cluster.ClusterApp.Values = values
+	}
+
+	clusterAppVersion, err := release.GetClusterAppVersion()
+	if err != nil {
+		return err
+	}
+	if strings.TrimPrefix(clusterApp.Spec.Version, "v") != strings.TrimPrefix(clusterAppVersion, "v") {
+		logger.Log("Updating cluster app '%s' from version '%s' to '%s'", clusterApp.Name, clusterApp.Spec.Version, clusterAppVersion)
+		patchedApp := clusterApp.DeepCopy()
+		patchedApp.Spec.Version = clusterAppVersion
+		if err := f.MC().Patch(ctx, patchedApp, cr.MergeFrom(clusterApp)); err != nil {
+			return fmt.Errorf("failed to update cluster app version: %w", err)
+		}
+		if cluster.ClusterApp != nil {
+			cluster.ClusterApp.Version = clusterAppVersion
+		}
+	}
+
+	f.registry.setClusterRelease(cluster.Name, release.Name)
+
+	return nil
+}
+
+// isControlPlaneUpgraded returns a WaitCondition that checks if the cluster's control plane resource reports the
+// expected Kubernetes version and is ready
+func (f *Framework) isControlPlaneUpgraded(ctx context.Context, cluster *application.Cluster, kubernetesVersion string) wait.WaitCondition {
+	return func() (bool, error) {
+		cp, err := f.GetControlPlaneResource(ctx, cluster.Name, cluster.GetNamespace())
+		if err != nil {
+			logger.Log("Failed to get control plane resource: %v", err)
+			return false, nil
+		}
+
+		version, _, _ := unstructured.NestedString(cp.Object, "status", "version")
+		if !isKubernetesVersion(version, kubernetesVersion) {
+			logger.Log("Control plane %s/%s at version '%s', expecting '%s'", cp.GetNamespace(), cp.GetName(), version, kubernetesVersion)
+			return false, nil
+		}
+
+		return wait.IsUnstructuredConditionSet(cp, "Ready", metav1.ConditionTrue, "")
+	}
+}
+
+// areAllWorkersUpgraded returns a WaitCondition that checks if all worker nodes in the workload cluster are ready and
+// running the expected Kubernetes version
+func areAllWorkersUpgraded(ctx context.Context, wcClient cr.Client, kubernetesVersion string) wait.WaitCondition {
+	return func() (bool, error) {
+		nodes := &corev1.NodeList{}
+		err := wcClient.List(ctx, nodes)
+		if err != nil {
+			logger.Log("Failed to list nodes: %v", err)
+			return false, nil
+		}
+
+		upgraded := true
+		for _, node := range nodes.Items {
+			if _, ok := node.Labels["node-role.kubernetes.io/control-plane"]; ok {
+				continue
+			}
+
+			ready := false
+			for _, condition := range node.Status.Conditions {
+				if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+					ready = true
+				}
+			}
+
+			kubeletVersion := node.Status.NodeInfo.KubeletVersion
+			if !ready || !isKubernetesVersion(kubeletVersion, kubernetesVersion) {
+				logger.Log("Worker node '%s' not yet upgraded: Ready='%t', KubeletVersion='%s', expecting '%s'", node.Name, ready, kubeletVersion, kubernetesVersion)
+				upgraded = false
+			}
+		}
+
+		return upgraded, nil
+	}
+}
+
+// areDefaultAppsUpgraded returns a WaitCondition that checks if all the default apps of the Release have been deployed
+// at the version specified in the Release. Each app may be deployed as either an App CR or a HelmRelease.
+func (f *Framework) areDefaultAppsUpgraded(ctx context.Context, cluster *application.Cluster, release *releases.Release) wait.WaitCondition {
+	return func() (bool, error) {
+		upgraded := true
+		for _, releaseApp := range release.Spec.Apps {
+			name := types.NamespacedName{Name: fmt.Sprintf("%s-%s", cluster.Name, releaseApp.Name), Namespace: cluster.GetNamespace()}
+
+			deployedVersion, ready := f.getDefaultAppState(ctx, name)
+			if !ready || strings.TrimPrefix(deployedVersion, "v") != strings.TrimPrefix(releaseApp.Version, "v") {
+				logger.Log("Default app '%s' not yet upgraded: Ready='%t', Version='%s', expecting '%s'", name.Name, ready, deployedVersion, releaseApp.Version)
+				upgraded = false
+			}
+		}
+
+		return upgraded, nil
+	}
+}
+
+// getDefaultAppState returns the currently deployed version of the named default app and whether it is ready
+func (f *Framework) getDefaultAppState(ctx context.Context, name types.NamespacedName) (string, bool) {
+	app := &applicationv1alpha1.App{}
+	if err := f.MC().Get(ctx, name, app); err == nil {
+		return app.Status.Version, app.Status.Release.Status == "deployed"
+	}
+
+	hr := &helmv2.HelmRelease{}
+	if err := f.MC().Get(ctx, name, hr); err == nil {
+		version := ""
+		if latest := hr.Status.History.Latest(); latest != nil {
+			version = latest.ChartVersion
+		}
+		return version, apimeta.IsStatusConditionTrue(hr.Status.Conditions, "Ready")
+	}
+
+	return "", false
+}
+
+// isKubernetesVersion checks if the actual version reported by a resource matches the expected Kubernetes version.
+// Providers may append extra build details (e.g. `v1.30.2-eks-1552ad0`) so only the prefix is compared.
+func isKubernetesVersion(actual string, expected string) bool {
+	actual = strings.TrimPrefix(actual, "v")
+	expected = strings.TrimPrefix(expected, "v")
+	return expected != "" && (actual == expected || strings.HasPrefix(actual, expected+"-") || strings.HasPrefix(actual, expected+"+"))
+}
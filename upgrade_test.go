@@ -0,0 +1,142 @@
+package clustertest
+
+import (
+	"context"
+	"testing"
+
+	applicationv1alpha1 "github.com/giantswarm/apiextensions-application/api/v1alpha1"
+	releases "github.com/giantswarm/releases/sdk/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/giantswarm/clustertest/v5/pkg/application"
+	"github.com/giantswarm/clustertest/v5/pkg/client"
+	"github.com/giantswarm/clustertest/v5/pkg/organization"
+)
+
+func TestIsKubernetesVersion(t *testing.T) {
+	tests := []struct {
+		actual   string
+		expected string
+		matches  bool
+	}{
+		{actual: "1.30.2", expected: "1.30.2", matches: true},
+		{actual: "v1.30.2", expected: "1.30.2", matches: true},
+		{actual: "1.30.2", expected: "v1.30.2", matches: true},
+		{actual: "v1.30.2", expected: "v1.30.2", matches: true},
+		{actual: "v1.30.2-eks-1552ad0", expected: "1.30.2", matches: true},
+		{actual: "v1.30.2+k3s1", expected: "v1.30.2", matches: true},
+		{actual: "v1.30.20", expected: "1.30.2", matches: false},
+		{actual: "v1.30.1", expected: "1.30.2", matches: false},
+		{actual: "v1.31.2", expected: "1.30.2", matches: false},
+		{actual: "", expected: "1.30.2", matches: false},
+		{actual: "v1.30.2", expected: "", matches: false},
+		{actual: "", expected: "", matches: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.actual+"_"+tc.expected, func(t *testing.T) {
+			if actual := isKubernetesVersion(tc.actual, tc.expected); actual != tc.matches {
+				t.Errorf("Match not as expected for '%s' and '%s'. Expected: %t, Actual: %t", tc.actual, tc.expected, tc.matches, actual)
+			}
+		})
+	}
+}
+
+func TestUpdateClusterRelease(t *testing.T) {
+	tests := []struct {
+		name                   string
+		values                 string
+		clusterAppVersion      string
+		expectedValues         string
+		expectedClusterVersion string
+	}{
+		{
+			name:                   "replaces existing release version",
+			values:                 "global:\n  release:\n    version: 29.0.0\n",
+			clusterAppVersion:      "2.0.0",
+			expectedValues:         "global:\n  release:\n    version: 30.1.0\n",
+			expectedClusterVersion: "3.0.0",
+		},
+		{
+			name:                   "keeps other values",
+			values:                 "global:\n  metadata:\n    description: upgrade test\n  release:\n    version: 29.0.0\n",
+			clusterAppVersion:      "2.0.0",
+			expectedValues:         "global:\n  metadata:\n    description: upgrade test\n  release:\n    version: 30.1.0\n",
+			expectedClusterVersion: "3.0.0",
+		},
+		{
+			name:                   "adds missing release version",
+			values:                 "global:\n  metadata:\n    name: example\n",
+			clusterAppVersion:      "2.0.0",
+			expectedValues:         "global:\n  metadata:\n    name: example\n  release:\n    version: 30.1.0\n",
+			expectedClusterVersion: "3.0.0",
+		},
+		{
+			name:                   "same cluster app version with prefix",
+			values:                 "global:\n  release:\n    version: 29.0.0\n",
+			clusterAppVersion:      "v3.0.0",
+			expectedValues:         "global:\n  release:\n    version: 30.1.0\n",
+			expectedClusterVersion: "v3.0.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cluster := application.NewClusterApp("t-upgrade", application.ProviderAWS).WithOrg(organization.New("test"))
+			namespace := cluster.GetNamespace()
+
+			clusterApp := &applicationv1alpha1.App{
+				ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: namespace},
+				Spec: applicationv1alpha1.AppSpec{
+					Version: tc.clusterAppVersion,
+					UserConfig: applicationv1alpha1.AppSpecUserConfig{
+						ConfigMap: applicationv1alpha1.AppSpecUserConfigConfigMap{Name: cluster.Name + "-userconfig", Namespace: namespace},
+					},
+				},
+			}
+			clusterValues := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: cluster.Name + "-userconfig", Namespace: namespace},
+				Data:       map[string]string{"values": tc.values},
+			}
+			f := &Framework{mcClient: &client.Client{Client: fake.NewClientBuilder().WithObjects(clusterApp, clusterValues).Build()}}
+			f.registry.addCluster(ClusterState{Cluster: cluster, ReleaseName: "aws-29.0.0"})
+
+			release := &releases.Release{
+				ObjectMeta: metav1.ObjectMeta{Name: "aws-30.1.0"},
+				Spec: releases.ReleaseSpec{
+					Components: []releases.ReleaseSpecComponent{{Name: "cluster-aws", Version: "3.0.0"}},
+				},
+			}
+			if err := f.updateClusterRelease(ctx, cluster, release); err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+
+			updatedValues := &corev1.ConfigMap{}
+			if err := f.MC().Get(ctx, types.NamespacedName{Name: clusterValues.Name, Namespace: namespace}, updatedValues); err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if updatedValues.Data["values"] != tc.expectedValues {
+				t.Errorf("Values not as expected.\nExpected:\n%s\nActual:\n%s", tc.expectedValues, updatedValues.Data["values"])
+			}
+			if cluster.ClusterApp.Values != tc.expectedValues {
+				t.Errorf("Expected the Cluster values to be updated. Actual:\n%s", cluster.ClusterApp.Values)
+			}
+
+			updatedApp := &applicationv1alpha1.App{}
+			if err := f.MC().Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: namespace}, updatedApp); err != nil {
+				t.Fatalf("Not expecting an error to be returned - %v", err)
+			}
+			if updatedApp.Spec.Version != tc.expectedClusterVersion {
+				t.Errorf("Cluster app version not as expected. Expected: %s, Actual: %s", tc.expectedClusterVersion, updatedApp.Spec.Version)
+			}
+
+			if clusters := f.GetState().Clusters; len(clusters) != 1 || clusters[0].ReleaseName != release.Name {
+				t.Errorf("Expected the cluster state to refer to the new Release. Actual: %+v", clusters)
+			}
+		})
+	}
+}